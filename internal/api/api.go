@@ -1,10 +1,3 @@
-//go:generate go run ec2/ec2_gen.go
-//go:generate go run iam/iam_gen.go
-//go:generate go run s3/s3_gen.go
-//go:generate gofmt -w ec2/
-//go:generate gofmt -w iam/
-//go:generate gofmt -w s3/
-
 package api
 
 import (
@@ -13,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -86,3 +81,24 @@ func Contains(s string, targets []string) bool {
 	}
 	return false
 }
+
+// Collect runs fn and returns the rows it sent to the channel.
+func Collect[T any](fn func(ich chan<- T) error) ([]T, error) {
+	ich := make(chan T, runtime.NumCPU())
+	var info []T
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range ich {
+			info = append(info, i)
+		}
+	}()
+	err := fn(ich)
+	close(ich)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
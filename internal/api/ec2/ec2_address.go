@@ -0,0 +1,219 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeAddressesInput(ids, names []string, filters []types.Filter, _ bool) *ec2.DescribeAddressesInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("allocation-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	return &ec2.DescribeAddressesInput{
+		Filters: f,
+	}
+}
+
+var AddressLister = &Lister[types.Address]{
+	Name:    "DescribeAddresses",
+	Filters: addressFilterNames,
+	List:    listAddresses,
+	Named: namedBy(func(item types.Address) []types.Tag {
+		return item.Tags
+	}),
+}
+
+// listAddresses requests every address of the region at once, as the API does not paginate.
+func listAddresses(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, _ *string) ([]types.Address, *string, error) {
+	input := CreateDescribeAddressesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeAddresses(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !in.Unassociated {
+		return o.Addresses, nil, nil
+	}
+	var addrs []types.Address
+	for _, addr := range o.Addresses {
+		if addr.AssociationId == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil, nil
+}
+
+type AddressInfo struct {
+	AllocationId       string
+	AddressName        string
+	PublicIp           string
+	Domain             types.DomainType
+	AssociationType    string
+	AssociationId      string
+	InstanceId         string
+	NatGatewayId       string
+	NetworkInterfaceId string
+	PrivateIpAddress   string
+	NetworkBorderGroup string
+	Region             string
+}
+
+// GetAddressInfo sends the addresses with what they are associated with.
+// Addresses of NAT gateways are associated with the network interface of the gateway.
+func GetAddressInfo(ich chan<- AddressInfo, addrs []types.Address, region string, nats map[string]types.NatGateway, n *Namer) {
+	natsByAllocation := natGatewaysByAllocation(nats)
+	for _, addr := range addrs {
+		obj := AddressInfo{
+			AllocationId:       aws.ToString(addr.AllocationId),
+			AddressName:        n.name(addr.Tags, addr.PublicIp, addr.AllocationId),
+			PublicIp:           aws.ToString(addr.PublicIp),
+			Domain:             addr.Domain,
+			AssociationId:      aws.ToString(addr.AssociationId),
+			InstanceId:         aws.ToString(addr.InstanceId),
+			NetworkInterfaceId: aws.ToString(addr.NetworkInterfaceId),
+			PrivateIpAddress:   aws.ToString(addr.PrivateIpAddress),
+			NetworkBorderGroup: aws.ToString(addr.NetworkBorderGroup),
+			Region:             region,
+		}
+		if nat, ok := natsByAllocation[obj.AllocationId]; ok {
+			obj.NatGatewayId = aws.ToString(nat.NatGatewayId)
+		}
+		obj.AssociationType = getEc2AddressAssociationType(obj.InstanceId, obj.NatGatewayId, obj.NetworkInterfaceId).String()
+		ich <- obj
+	}
+}
+
+type AddressAssociationInfo struct {
+	AllocationId         string
+	AddressName          string
+	PublicIp             string
+	AssociationType      string
+	AssociatedId         string
+	AssociatedName       string
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	PrivateIpAddress     string
+	Region               string
+}
+
+func FetchDataForAddressAssociationInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Instance, map[string]types.NatGateway, map[string]types.NetworkInterface, error) {
+	var instances map[string]types.Instance
+	var nats map[string]types.NatGateway
+	var enis map[string]types.NetworkInterface
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		instances, err = client.FetchInstances(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		nats, err = client.FetchNatGateways(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		enis, err = client.FetchNetworkInterfaces(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+	return instances, nats, enis, nil
+}
+
+// GetAddressAssociationInfo sends the addresses with the name of the instance, NAT gateway
+// or network interface they are associated with.
+func GetAddressAssociationInfo(ich chan<- AddressAssociationInfo, addrs []types.Address, region string, instances map[string]types.Instance, nats map[string]types.NatGateway, enis map[string]types.NetworkInterface, n *Namer) {
+	natsByAllocation := natGatewaysByAllocation(nats)
+	for _, addr := range addrs {
+		obj := AddressAssociationInfo{
+			AllocationId:       aws.ToString(addr.AllocationId),
+			AddressName:        n.name(addr.Tags, addr.PublicIp, addr.AllocationId),
+			PublicIp:           aws.ToString(addr.PublicIp),
+			NetworkInterfaceId: aws.ToString(addr.NetworkInterfaceId),
+			PrivateIpAddress:   aws.ToString(addr.PrivateIpAddress),
+			Region:             region,
+		}
+		if eni, ok := enis[obj.NetworkInterfaceId]; ok {
+			obj.NetworkInterfaceName = n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId)
+		}
+		instanceId := aws.ToString(addr.InstanceId)
+		var natGatewayId string
+		nat, ok := natsByAllocation[obj.AllocationId]
+		if ok {
+			natGatewayId = aws.ToString(nat.NatGatewayId)
+		}
+		t := getEc2AddressAssociationType(instanceId, natGatewayId, obj.NetworkInterfaceId)
+		obj.AssociationType = t.String()
+		switch t {
+		case associationTypeInstance:
+			obj.AssociatedId = instanceId
+			obj.AssociatedName = instanceId
+			if i, ok := instances[instanceId]; ok {
+				obj.AssociatedName = n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+			}
+		case associationTypeNatGateway:
+			obj.AssociatedId = natGatewayId
+			obj.AssociatedName = n.name(nat.Tags, nat.NatGatewayId)
+		case associationTypeNetworkInterface:
+			obj.AssociatedId = obj.NetworkInterfaceId
+			obj.AssociatedName = obj.NetworkInterfaceName
+		}
+		ich <- obj
+	}
+}
+
+func getEc2AddressAssociationType(instanceId, natGatewayId, networkInterfaceId string) associationType {
+	switch {
+	case instanceId != "":
+		return associationTypeInstance
+	case natGatewayId != "":
+		return associationTypeNatGateway
+	case networkInterfaceId != "":
+		return associationTypeNetworkInterface
+	default:
+		return associationTypeNone
+	}
+}
+
+func natGatewaysByAllocation(nats map[string]types.NatGateway) map[string]types.NatGateway {
+	res := make(map[string]types.NatGateway)
+	for _, nat := range nats {
+		for _, addr := range nat.NatGatewayAddresses {
+			if id := aws.ToString(addr.AllocationId); id != "" {
+				res[id] = nat
+			}
+		}
+	}
+	return res
+}
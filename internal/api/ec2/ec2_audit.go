@@ -0,0 +1,171 @@
+package ec2
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+)
+
+// AuditRules configures the audit of security group rules. Only ingress rules are audited.
+// Fields left empty in a rule file keep the values of DefaultAuditRules.
+type AuditRules struct {
+	// AdminPorts are the ports flagged as WorldOpenAdminPort when open to 0.0.0.0/0 or ::/0.
+	AdminPorts []int32 `json:"adminPorts"`
+
+	// BroadPrefixLength flags IPv4 CIDR blocks with a shorter prefix as BroadCidr.
+	BroadPrefixLength int `json:"broadPrefixLength"`
+
+	// Severities maps findings to Critical, High, Medium, Low or None, which disables the finding.
+	Severities map[string]string `json:"severities"`
+}
+
+// DefaultAuditRules are the audit rules applied unless a rule file is passed.
+var DefaultAuditRules = &AuditRules{
+	AdminPorts:        []int32{21, 22, 23, 135, 139, 445, 1433, 1521, 2379, 3306, 3389, 5432, 5601, 5984, 6379, 9200, 11211, 27017},
+	BroadPrefixLength: 16,
+	Severities: map[string]string{
+		auditFindingWorldOpenAdminPort.String(): severityCritical.String(),
+		auditFindingAllTraffic.String():         severityHigh.String(),
+		auditFindingAllPorts.String():           severityHigh.String(),
+		auditFindingIpv6WorldAccess.String():    severityHigh.String(),
+		auditFindingBroadCidr.String():          severityMedium.String(),
+		auditFindingWorldOpen.String():          severityLow.String(),
+	},
+}
+
+// ParseEc2AuditRules parses a jsonnet file evaluating to audit rules such as
+// '{adminPorts: [22, 3389], broadPrefixLength: 8, severities: {WorldOpen: "None"}}'.
+func ParseEc2AuditRules(path string) (*AuditRules, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("cannot read audit rule file: %w", err)
+	}
+	j, err := toJSON(nil, func(vm *jsonnet.VM) (string, error) {
+		vm.Importer(&jsonnet.FileImporter{})
+		return vm.EvaluateFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var rules AuditRules
+	if err := json.Unmarshal([]byte(j), &rules); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal audit rules: %w", err)
+	}
+	return mergeAuditRules(&rules)
+}
+
+func mergeAuditRules(rules *AuditRules) (*AuditRules, error) {
+	res := &AuditRules{
+		AdminPorts:        DefaultAuditRules.AdminPorts,
+		BroadPrefixLength: DefaultAuditRules.BroadPrefixLength,
+		Severities:        make(map[string]string),
+	}
+	if rules.AdminPorts != nil {
+		res.AdminPorts = rules.AdminPorts
+	}
+	if rules.BroadPrefixLength != 0 {
+		res.BroadPrefixLength = rules.BroadPrefixLength
+	}
+	for k, v := range DefaultAuditRules.Severities {
+		res.Severities[k] = v
+	}
+	for k, v := range rules.Severities {
+		if !slices.Contains(auditFindings, k) {
+			return nil, fmt.Errorf("invalid finding in audit rules: %s: valid values: %s", k, strings.Join(auditFindings, "|"))
+		}
+		if !slices.ContainsFunc(severities, func(s string) bool { return strings.EqualFold(s, v) }) {
+			return nil, fmt.Errorf("invalid severity in audit rules: %s: valid values: %s", v, strings.Join(severities, "|"))
+		}
+		res.Severities[k] = v
+	}
+	return res, nil
+}
+
+// severity returns the severity of the finding, normalized to the case of the severity names.
+// Findings missing in the rules keep their default severity.
+func (r *AuditRules) severity(f auditFinding) string {
+	v, ok := r.Severities[f.String()]
+	if !ok {
+		v = DefaultAuditRules.Severities[f.String()]
+	}
+	for _, s := range severities {
+		if strings.EqualFold(s, v) {
+			return s
+		}
+	}
+	return severityNone.String()
+}
+
+type auditResult struct {
+	Finding  string
+	Severity string
+}
+
+// audit returns the findings of an ingress rule with their severities, skipping disabled ones.
+// Rules referencing security groups and prefix lists are not audited.
+func (r *AuditRules) audit(perm SecurityGroupPermissionsInfo) []auditResult {
+	if r == nil {
+		r = DefaultAuditRules
+	}
+	if perm.FlowDirection != Ingress.String() {
+		return nil
+	}
+	var findings []auditFinding
+	allProtocols := perm.IpProtocol == "-1"
+	portRange := isEc2PortRangeProtocol(perm.IpProtocol)
+	switch {
+	case allProtocols:
+		findings = append(findings, auditFindingAllTraffic)
+	case portRange && perm.FromPort <= 0 && perm.ToPort >= 65535:
+		findings = append(findings, auditFindingAllPorts)
+	}
+	switch perm.AddressType {
+	case addressTypeIpv4.String(), addressTypeIpv6.String():
+	default:
+		return r.results(findings)
+	}
+	prefix, err := netip.ParsePrefix(perm.CidrBlock)
+	if err != nil {
+		return r.results(findings)
+	}
+	world := prefix.Bits() == 0
+	if world && (allProtocols || portRange) && slices.ContainsFunc(r.AdminPorts, func(p int32) bool {
+		return allProtocols || perm.FromPort <= p && p <= perm.ToPort
+	}) {
+		findings = append(findings, auditFindingWorldOpenAdminPort)
+	}
+	switch {
+	case world && prefix.Addr().Is6():
+		findings = append(findings, auditFindingIpv6WorldAccess)
+	case world:
+		findings = append(findings, auditFindingWorldOpen)
+	case prefix.Addr().Is4() && prefix.Bits() < cmp.Or(r.BroadPrefixLength, DefaultAuditRules.BroadPrefixLength):
+		findings = append(findings, auditFindingBroadCidr)
+	}
+	return r.results(findings)
+}
+
+func (r *AuditRules) results(findings []auditFinding) []auditResult {
+	var res []auditResult
+	for _, f := range findings {
+		s := r.severity(f)
+		if s == severityNone.String() {
+			continue
+		}
+		res = append(res, auditResult{
+			Finding:  f.String(),
+			Severity: s,
+		})
+	}
+	return res
+}
+
+// CompareSeverity orders severities from Critical to None.
+func CompareSeverity(a, b string) int {
+	return cmp.Compare(slices.Index(severities, b), slices.Index(severities, a))
+}
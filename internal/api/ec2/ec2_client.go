@@ -17,8 +17,18 @@ type IEc2Client interface {
 	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
 	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
 	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
-
+	DescribeAddresses(ctx context.Context, params *ec2.DescribeAddressesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAddressesOutput, error)
+	DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeVolumes(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeNetworkAcls(ctx context.Context, params *ec2.DescribeNetworkAclsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkAclsOutput, error)
+	DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeVpcPeeringConnections(ctx context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+	DescribeTransitGateways(ctx context.Context, params *ec2.DescribeTransitGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewaysOutput, error)
+	DescribeAvailabilityZones(ctx context.Context, params *ec2.DescribeAvailabilityZonesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeAvailabilityZonesOutput, error)
+
+	FetchInstances(ctx context.Context, region string) (map[string]types.Instance, error)
 	FetchImages(ctx context.Context, region string) (map[string]types.Image, error)
+	FetchOwnedImages(ctx context.Context, region string) (map[string]types.Image, error)
 	FetchSnapshots(ctx context.Context, region string) (map[string]types.Snapshot, error)
 	FetchVolumes(ctx context.Context, region string) (map[string]types.Volume, error)
 	FetchSecurityGroups(ctx context.Context, region string) (map[string]types.SecurityGroup, error)
@@ -28,8 +38,28 @@ type IEc2Client interface {
 	FetchPrefixLists(ctx context.Context, region string) (map[string]types.PrefixList, error)
 	FetchManagedPrefixLists(ctx context.Context, region string) (map[string]types.ManagedPrefixList, error)
 	FetchDhcpOptions(ctx context.Context, region string) (map[string]types.DhcpOptions, error)
+	FetchNetworkInterfaces(ctx context.Context, region string) (map[string]types.NetworkInterface, error)
+	FetchNatGateways(ctx context.Context, region string) (map[string]types.NatGateway, error)
+	FetchNetworkAcls(ctx context.Context, region string) (map[string]types.NetworkAcl, error)
+	FetchInternetGateways(ctx context.Context, region string) (map[string]types.InternetGateway, error)
+	FetchEgressOnlyInternetGateways(ctx context.Context, region string) (map[string]types.EgressOnlyInternetGateway, error)
+	FetchVpcEndpoints(ctx context.Context, region string) (map[string]types.VpcEndpoint, error)
+	FetchVpcPeeringConnections(ctx context.Context, region string) (map[string]types.VpcPeeringConnection, error)
+	FetchTransitGateways(ctx context.Context, region string) (map[string]types.TransitGateway, error)
+	FetchTransitGatewayVpcAttachments(ctx context.Context, region string) (map[string]types.TransitGatewayVpcAttachment, error)
+	FetchVpnGateways(ctx context.Context, region string) (map[string]types.VpnGateway, error)
+	FetchTransitGatewayAttachments(ctx context.Context, region string) (map[string]types.TransitGatewayAttachment, error)
+	FetchTransitGatewayRouteTables(ctx context.Context, region string) (map[string]types.TransitGatewayRouteTable, error)
+	FetchVpnConnections(ctx context.Context, region string) (map[string]types.VpnConnection, error)
+	FetchTags(ctx context.Context, region string, keys []string) (map[string]map[string]string, error)
 	GetVpcDnsSupport(ctx context.Context, region string, id *string) (bool, error)
 	GetVpcDnsHostnames(ctx context.Context, region string, id *string) (bool, error)
+	GetSnapshotCreateVolumePermissions(ctx context.Context, region string, id *string) ([]types.CreateVolumePermission, error)
+	GetTransitGatewayRouteTableAssociations(ctx context.Context, region string, id *string) ([]types.TransitGatewayRouteTableAssociation, error)
+	GetTransitGatewayRouteTablePropagations(ctx context.Context, region string, id *string) ([]types.TransitGatewayRouteTablePropagation, error)
+	SearchTransitGatewayRoutes(ctx context.Context, region string, id *string) ([]types.TransitGatewayRoute, error)
+	GetStaleSecurityGroups(ctx context.Context, region string, id *string) ([]types.StaleSecurityGroup, error)
+	GetManagedPrefixListEntries(ctx context.Context, region string, id *string) ([]types.PrefixListEntry, error)
 }
 
 type Ec2Client struct {
@@ -40,10 +70,18 @@ func NewEc2Client(cfg *aws.Config) *Ec2Client {
 	return &Ec2Client{Client: ec2.NewFromConfig(*cfg)}
 }
 
+func (client *Ec2Client) FetchInstances(ctx context.Context, region string) (map[string]types.Instance, error) {
+	return fetchEc2Instances(ctx, client.Client, region)
+}
+
 func (client *Ec2Client) FetchImages(ctx context.Context, region string) (map[string]types.Image, error) {
 	return fetchEc2Images(ctx, client.Client, region)
 }
 
+func (client *Ec2Client) FetchOwnedImages(ctx context.Context, region string) (map[string]types.Image, error) {
+	return fetchEc2OwnedImages(ctx, client.Client, region)
+}
+
 func (client *Ec2Client) FetchSnapshots(ctx context.Context, region string) (map[string]types.Snapshot, error) {
 	return fetchEc2Snapshots(ctx, client.Client, region)
 }
@@ -80,6 +118,62 @@ func (client *Ec2Client) FetchDhcpOptions(ctx context.Context, region string) (m
 	return fetchEc2DhcpOptions(ctx, client.Client, region)
 }
 
+func (client *Ec2Client) FetchNetworkInterfaces(ctx context.Context, region string) (map[string]types.NetworkInterface, error) {
+	return fetchEc2NetworkInterfaces(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchNatGateways(ctx context.Context, region string) (map[string]types.NatGateway, error) {
+	return fetchEc2NatGateways(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchNetworkAcls(ctx context.Context, region string) (map[string]types.NetworkAcl, error) {
+	return fetchEc2NetworkAcls(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchInternetGateways(ctx context.Context, region string) (map[string]types.InternetGateway, error) {
+	return fetchEc2InternetGateways(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchEgressOnlyInternetGateways(ctx context.Context, region string) (map[string]types.EgressOnlyInternetGateway, error) {
+	return fetchEc2EgressOnlyInternetGateways(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchVpcEndpoints(ctx context.Context, region string) (map[string]types.VpcEndpoint, error) {
+	return fetchEc2VpcEndpoints(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchVpcPeeringConnections(ctx context.Context, region string) (map[string]types.VpcPeeringConnection, error) {
+	return fetchEc2VpcPeeringConnections(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchTransitGateways(ctx context.Context, region string) (map[string]types.TransitGateway, error) {
+	return fetchEc2TransitGateways(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchTransitGatewayVpcAttachments(ctx context.Context, region string) (map[string]types.TransitGatewayVpcAttachment, error) {
+	return fetchEc2TransitGatewayVpcAttachments(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchVpnGateways(ctx context.Context, region string) (map[string]types.VpnGateway, error) {
+	return fetchEc2VpnGateways(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchTransitGatewayAttachments(ctx context.Context, region string) (map[string]types.TransitGatewayAttachment, error) {
+	return fetchEc2TransitGatewayAttachments(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchTransitGatewayRouteTables(ctx context.Context, region string) (map[string]types.TransitGatewayRouteTable, error) {
+	return fetchEc2TransitGatewayRouteTables(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchVpnConnections(ctx context.Context, region string) (map[string]types.VpnConnection, error) {
+	return fetchEc2VpnConnections(ctx, client.Client, region)
+}
+
+func (client *Ec2Client) FetchTags(ctx context.Context, region string, keys []string) (map[string]map[string]string, error) {
+	return fetchEc2Tags(ctx, client.Client, region, keys)
+}
+
 func (client *Ec2Client) GetVpcDnsSupport(ctx context.Context, region string, id *string) (bool, error) {
 	return getEc2VpcDnsSupport(ctx, client.Client, region, id)
 }
@@ -87,3 +181,27 @@ func (client *Ec2Client) GetVpcDnsSupport(ctx context.Context, region string, id
 func (client *Ec2Client) GetVpcDnsHostnames(ctx context.Context, region string, id *string) (bool, error) {
 	return getEc2VpcDnsHostnames(ctx, client.Client, region, id)
 }
+
+func (client *Ec2Client) GetSnapshotCreateVolumePermissions(ctx context.Context, region string, id *string) ([]types.CreateVolumePermission, error) {
+	return getEc2SnapshotCreateVolumePermissions(ctx, client.Client, region, id)
+}
+
+func (client *Ec2Client) GetTransitGatewayRouteTableAssociations(ctx context.Context, region string, id *string) ([]types.TransitGatewayRouteTableAssociation, error) {
+	return getEc2TransitGatewayRouteTableAssociations(ctx, client.Client, region, id)
+}
+
+func (client *Ec2Client) GetTransitGatewayRouteTablePropagations(ctx context.Context, region string, id *string) ([]types.TransitGatewayRouteTablePropagation, error) {
+	return getEc2TransitGatewayRouteTablePropagations(ctx, client.Client, region, id)
+}
+
+func (client *Ec2Client) SearchTransitGatewayRoutes(ctx context.Context, region string, id *string) ([]types.TransitGatewayRoute, error) {
+	return searchEc2TransitGatewayRoutes(ctx, client.Client, region, id)
+}
+
+func (client *Ec2Client) GetStaleSecurityGroups(ctx context.Context, region string, id *string) ([]types.StaleSecurityGroup, error) {
+	return getEc2StaleSecurityGroups(ctx, client.Client, region, id)
+}
+
+func (client *Ec2Client) GetManagedPrefixListEntries(ctx context.Context, region string, id *string) ([]types.PrefixListEntry, error) {
+	return getEc2ManagedPrefixListEntries(ctx, client.Client, region, id)
+}
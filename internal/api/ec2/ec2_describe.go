@@ -0,0 +1,120 @@
+package ec2
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Input narrows the resources requested from a describe API.
+type Input struct {
+	Ids           []string
+	Names         []string
+	Filters       []types.Filter
+	DefaultFilter bool
+	Namer         *Namer
+
+	// Unattached narrows volumes to the ones not attached to any instance.
+	Unattached bool
+
+	// OlderThan narrows snapshots to the ones started before the duration elapsed.
+	OlderThan time.Duration
+
+	// Unassociated narrows addresses to the ones not associated with any resource.
+	Unassociated bool
+
+	// Unused narrows security groups to the ones not attached to any network interface, except default ones.
+	Unused bool
+
+	// AuditRules configures the audit of security group rules. DefaultAuditRules is applied when nil.
+	AuditRules *AuditRules
+
+	// ExpandPrefixLists expands rules and routes referencing a prefix list to a row per CIDR block.
+	ExpandPrefixLists bool
+
+	// MinUtilization narrows subnets and VPCs to the ones using at least the percentage of their IPv4 addresses.
+	MinUtilization float64
+}
+
+// Lister declares a describe API.
+type Lister[I any] struct {
+	// Name is the name of the API, such as DescribeInstances.
+	Name string
+
+	// Filters are the filter names accepted by the API, besides "tag:<key>".
+	Filters []string
+
+	// List requests a page in the region and returns the items and the next token.
+	// The limiter has been waited for the page; List waits for it again before any further request.
+	List func(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]I, *string, error)
+
+	// Named narrows the items to the ones, or the parts of them, named by their tags as one of the names.
+	// It is set for resources named by tags, whose names List matches against the Name tag only;
+	// Describe lists them without names and narrows them by Named when names are read from other tag keys.
+	Named func(items []I, n *Namer, names []string) []I
+}
+
+// Validate checks the filter names of the input against the ones accepted by the API.
+func (list *Lister[I]) Validate(in *Input) error {
+	return validateFilterNames(list.Name, list.Filters, in.Filters)
+}
+
+// Builder sends the rows built from a page of listed items.
+type Builder[I, T any] func(ich chan<- T, items []I) error
+
+// Handler fetches the data the rows of the region depend on and returns their builder.
+// The input is a copy owned by the region and may be narrowed by the handler.
+type Handler[I, T any] func(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, in *Input) (Builder[I, T], error)
+
+// Describe lists the items of every region in parallel and returns the rows built from them.
+func Describe[I, T any](ctx context.Context, client IEc2Client, regions []string, in *Input, list *Lister[I], handle Handler[I, T]) ([]T, error) {
+	if err := list.Validate(in); err != nil {
+		return nil, err
+	}
+	return api.Collect(func(ich chan<- T) error {
+		eg, ctx := errgroup.WithContext(ctx)
+		for _, region := range regions {
+			region := region
+			in := *in
+			// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/throttling.html
+			l := rate.NewLimiter(rate.Limit(50), 1)
+			eg.Go(func() error {
+				build, err := handle(ctx, l, client, region, &in)
+				if err != nil {
+					return err
+				}
+				listIn := in
+				var names []string
+				if len(in.Names) > 0 && list.Named != nil && !in.Namer.readsNameTag() {
+					names, listIn.Names = in.Names, nil
+				}
+				var token *string
+				for {
+					if err := l.Wait(ctx); err != nil {
+						return err
+					}
+					items, next, err := list.List(ctx, l, client, region, &listIn, token)
+					if err != nil {
+						return err
+					}
+					if names != nil {
+						items = list.Named(items, in.Namer, names)
+					}
+					if err := build(ich, items); err != nil {
+						return err
+					}
+					token = next
+					if token == nil {
+						break
+					}
+				}
+				return nil
+			})
+		}
+		return eg.Wait()
+	})
+}
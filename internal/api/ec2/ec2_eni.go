@@ -0,0 +1,324 @@
+package ec2
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeNetworkInterfacesInput(ids, names []string, filters []types.Filter, _ bool) *ec2.DescribeNetworkInterfacesInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("network-interface-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	return &ec2.DescribeNetworkInterfacesInput{
+		Filters: f,
+	}
+}
+
+var NetworkInterfaceLister = &Lister[types.NetworkInterface]{
+	Name:    "DescribeNetworkInterfaces",
+	Filters: networkInterfaceFilterNames,
+	List:    listNetworkInterfaces,
+	Named: namedBy(func(item types.NetworkInterface) []types.Tag {
+		return item.TagSet
+	}),
+}
+
+func listNetworkInterfaces(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.NetworkInterface, *string, error) {
+	input := CreateDescribeNetworkInterfacesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeNetworkInterfaces(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.NetworkInterfaces, o.NextToken, nil
+}
+
+type NetworkInterfaceInfo struct {
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	InterfaceType        types.NetworkInterfaceType
+	Status               types.NetworkInterfaceStatus
+	Description          string
+	VpcId                string
+	VpcName              string
+	SubnetId             string
+	SubnetName           string
+	PrivateIpAddress     string
+	AvailabilityZone     string
+	Region               string
+}
+
+func FetchDataForNetworkInterfaceInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.Subnet, error) {
+	vpcs := make(map[string]types.Vpc)
+	sbns := make(map[string]types.Subnet)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		sbns, err = client.FetchSubnets(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return vpcs, sbns, nil
+}
+
+func GetNetworkInterfaceInfo(ich chan<- NetworkInterfaceInfo, enis []types.NetworkInterface, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet, n *Namer) error {
+	for _, eni := range enis {
+		vpcId := aws.ToString(eni.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		subnetId := aws.ToString(eni.SubnetId)
+		sbn, err := findEc2SubnetById(subnetId, sbns)
+		if err != nil {
+			return err
+		}
+		ich <- NetworkInterfaceInfo{
+			NetworkInterfaceId:   aws.ToString(eni.NetworkInterfaceId),
+			NetworkInterfaceName: n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId),
+			InterfaceType:        eni.InterfaceType,
+			Status:               eni.Status,
+			Description:          aws.ToString(eni.Description),
+			VpcId:                vpcId,
+			VpcName:              n.name(vpc.Tags, vpc.VpcId),
+			SubnetId:             subnetId,
+			SubnetName:           n.name(sbn.Tags, sbn.SubnetId),
+			PrivateIpAddress:     aws.ToString(eni.PrivateIpAddress),
+			AvailabilityZone:     aws.ToString(eni.AvailabilityZone),
+			Region:               region,
+		}
+	}
+	return nil
+}
+
+type NetworkInterfaceAttachmentInfo struct {
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	InterfaceType        types.NetworkInterfaceType
+	Owner                string
+	RequesterId          string
+	RequesterManaged     bool
+	AttachmentId         string
+	AttachmentStatus     types.AttachmentStatus
+	InstanceId           string
+	InstanceName         string
+	DeviceIndex          int32
+	DeleteOnTermination  bool
+	Region               string
+}
+
+func GetNetworkInterfaceAttachmentInfo(ich chan<- NetworkInterfaceAttachmentInfo, enis []types.NetworkInterface, region string, instances map[string]types.Instance, n *Namer) {
+	for _, eni := range enis {
+		obj := NetworkInterfaceAttachmentInfo{
+			NetworkInterfaceId:   aws.ToString(eni.NetworkInterfaceId),
+			NetworkInterfaceName: n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId),
+			InterfaceType:        eni.InterfaceType,
+			Owner:                getEc2NetworkInterfaceOwner(eni),
+			RequesterId:          aws.ToString(eni.RequesterId),
+			RequesterManaged:     aws.ToBool(eni.RequesterManaged),
+			Region:               region,
+		}
+		if a := eni.Attachment; a != nil {
+			obj.AttachmentId = aws.ToString(a.AttachmentId)
+			obj.AttachmentStatus = a.Status
+			obj.InstanceId = aws.ToString(a.InstanceId)
+			obj.DeviceIndex = aws.ToInt32(a.DeviceIndex)
+			obj.DeleteOnTermination = aws.ToBool(a.DeleteOnTermination)
+			if i, ok := instances[obj.InstanceId]; ok {
+				obj.InstanceName = n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+			}
+		}
+		ich <- obj
+	}
+}
+
+// networkInterfaceOwners maps the description prefixes of requester-managed
+// interfaces of type "interface" to the service owning them.
+var networkInterfaceOwners = []struct {
+	prefix string
+	owner  string
+}{
+	{"ELB ", "elb"},
+	{"RDSNetworkInterface", "rds"},
+	{"AWS Lambda VPC ENI", "lambda"},
+	{"Amazon EKS", "eks"},
+	{"EFS mount target", "efs"},
+	{"ElastiCache", "elasticache"},
+	{"DMSNetworkInterface", "dms"},
+	{"AWS CodeBuild", "codebuild"},
+	{"arn:aws:ecs:", "ecs"},
+}
+
+// getEc2NetworkInterfaceOwner returns what the interface belongs to: "instance" for an
+// interface attached to an instance by its owner, or the service managing it.
+func getEc2NetworkInterfaceOwner(eni types.NetworkInterface) string {
+	if eni.InterfaceType != "" && eni.InterfaceType != types.NetworkInterfaceTypeInterface {
+		return string(eni.InterfaceType)
+	}
+	if aws.ToBool(eni.RequesterManaged) {
+		desc := aws.ToString(eni.Description)
+		for _, o := range networkInterfaceOwners {
+			if strings.HasPrefix(desc, o.prefix) {
+				return o.owner
+			}
+		}
+		return aws.ToString(eni.RequesterId)
+	}
+	if eni.Attachment != nil && aws.ToString(eni.Attachment.InstanceId) != "" {
+		return "instance"
+	}
+	return ""
+}
+
+type NetworkInterfaceSecurityGroupInfo struct {
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	InterfaceType        types.NetworkInterfaceType
+	VpcId                string
+	VpcName              string
+	SecurityGroupId      string
+	SecurityGroupName    string
+	FlowDirection        string
+	IpProtocol           string
+	FromPort             int32
+	ToPort               int32
+	AddressType          string
+	CidrBlock            string
+	Region               string
+}
+
+func FetchDataForNetworkInterfaceSecurityGroupInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.SecurityGroup, map[string]types.Vpc, map[string]types.PrefixList, map[string]types.ManagedPrefixList, error) {
+	return FetchDataForInstanceSecurityGroupInfo(ctx, l, client, region)
+}
+
+func GetNetworkInterfaceSecurityGroupInfo(ich chan<- NetworkInterfaceSecurityGroupInfo, enis []types.NetworkInterface, region string, segs map[string]types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, n *Namer) error {
+	for _, eni := range enis {
+		vpcId := aws.ToString(eni.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		for _, seg := range eni.Groups {
+			obj := NetworkInterfaceSecurityGroupInfo{
+				NetworkInterfaceId:   aws.ToString(eni.NetworkInterfaceId),
+				NetworkInterfaceName: n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId),
+				InterfaceType:        eni.InterfaceType,
+				VpcId:                vpcId,
+				VpcName:              n.name(vpc.Tags, vpc.VpcId),
+				SecurityGroupId:      aws.ToString(seg.GroupId),
+				SecurityGroupName:    aws.ToString(seg.GroupName),
+				Region:               region,
+			}
+			sg, err := findEc2SecurityGroupById(aws.ToString(seg.GroupId), segs)
+			if err != nil {
+				return err
+			}
+			if err = appendToNetworkInterfaceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, false, region, n); err != nil {
+				return err
+			}
+			if err = appendToNetworkInterfaceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, true, region, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func appendToNetworkInterfaceSecurityGroupInfo(ich chan<- NetworkInterfaceSecurityGroupInfo, obj NetworkInterfaceSecurityGroupInfo, sg types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, isEgress bool, region string, n *Namer) error {
+	perms, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, nil, isEgress, region, n)
+	if err != nil {
+		return err
+	}
+	for _, perm := range perms {
+		obj.FlowDirection = perm.FlowDirection
+		obj.IpProtocol = perm.IpProtocol
+		obj.FromPort = perm.FromPort
+		obj.ToPort = perm.ToPort
+		obj.AddressType = perm.AddressType
+		obj.CidrBlock = perm.CidrBlock
+		ich <- obj
+	}
+	return nil
+}
+
+type NetworkInterfaceIpInfo struct {
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	InterfaceType        types.NetworkInterfaceType
+	AddressType          string
+	PrivateIpAddress     string
+	Primary              bool
+	PublicIp             string
+	PublicDnsName        string
+	ElasticIp            bool
+	AllocationId         string
+	IpOwnerId            string
+	Region               string
+}
+
+func GetNetworkInterfaceIpInfo(ich chan<- NetworkInterfaceIpInfo, enis []types.NetworkInterface, region string, n *Namer) {
+	for _, eni := range enis {
+		obj := NetworkInterfaceIpInfo{
+			NetworkInterfaceId:   aws.ToString(eni.NetworkInterfaceId),
+			NetworkInterfaceName: n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId),
+			InterfaceType:        eni.InterfaceType,
+			Region:               region,
+		}
+		for _, ip := range eni.PrivateIpAddresses {
+			row := obj
+			row.AddressType = addressTypeIpv4.String()
+			row.PrivateIpAddress = aws.ToString(ip.PrivateIpAddress)
+			row.Primary = aws.ToBool(ip.Primary)
+			if a := ip.Association; a != nil {
+				row.PublicIp = aws.ToString(a.PublicIp)
+				row.PublicDnsName = aws.ToString(a.PublicDnsName)
+				row.AllocationId = aws.ToString(a.AllocationId)
+				row.ElasticIp = row.AllocationId != ""
+				row.IpOwnerId = aws.ToString(a.IpOwnerId)
+			}
+			ich <- row
+		}
+		for _, ip := range eni.Ipv6Addresses {
+			row := obj
+			row.AddressType = addressTypeIpv6.String()
+			row.PrivateIpAddress = aws.ToString(ip.Ipv6Address)
+			row.Primary = aws.ToBool(ip.IsPrimaryIpv6)
+			ich <- row
+		}
+	}
+}
@@ -84,3 +84,287 @@ func (f flowDirection) String() string {
 	}
 	return ""
 }
+
+type sharingType int
+
+const (
+	sharingTypePrivate sharingType = iota
+	sharingTypeCrossAccount
+	sharingTypePublic
+)
+
+var sharingTypes = []string{
+	"Private",
+	"CrossAccount",
+	"Public",
+}
+
+func (s sharingType) String() string {
+	if s >= 0 && int(s) < len(sharingTypes) {
+		return sharingTypes[s]
+	}
+	return ""
+}
+
+type associationType int
+
+const (
+	associationTypeNone associationType = iota
+	associationTypeInstance
+	associationTypeNatGateway
+	associationTypeNetworkInterface
+)
+
+var associationTypes = []string{
+	"None",
+	"Instance",
+	"NatGateway",
+	"NetworkInterface",
+}
+
+func (a associationType) String() string {
+	if a >= 0 && int(a) < len(associationTypes) {
+		return associationTypes[a]
+	}
+	return ""
+}
+
+type routeFinding int
+
+const (
+	routeFindingBlackhole routeFinding = iota
+	routeFindingTargetMissing
+	routeFindingTargetNotAvailable
+	routeFindingNoAssociation
+)
+
+var routeFindings = []string{
+	"Blackhole",
+	"TargetMissing",
+	"TargetNotAvailable",
+	"NoAssociation",
+}
+
+func (r routeFinding) String() string {
+	if r >= 0 && int(r) < len(routeFindings) {
+		return routeFindings[r]
+	}
+	return ""
+}
+
+type bindingType int
+
+const (
+	bindingTypeAssociation bindingType = iota
+	bindingTypePropagation
+)
+
+var bindingTypes = []string{
+	"Association",
+	"Propagation",
+}
+
+func (b bindingType) String() string {
+	if b >= 0 && int(b) < len(bindingTypes) {
+		return bindingTypes[b]
+	}
+	return ""
+}
+
+type peeringSide int
+
+const (
+	peeringSideRequester peeringSide = iota
+	peeringSideAccepter
+)
+
+var peeringSides = []string{
+	"Requester",
+	"Accepter",
+}
+
+func (p peeringSide) String() string {
+	if p >= 0 && int(p) < len(peeringSides) {
+		return peeringSides[p]
+	}
+	return ""
+}
+
+type routeCheck int
+
+const (
+	routeCheckRouted routeCheck = iota
+	routeCheckMissing
+	routeCheckRoutedElsewhere
+	routeCheckBlackhole
+	routeCheckUnknown
+)
+
+var routeChecks = []string{
+	"Routed",
+	"Missing",
+	"RoutedElsewhere",
+	"Blackhole",
+	"Unknown",
+}
+
+func (r routeCheck) String() string {
+	if r >= 0 && int(r) < len(routeChecks) {
+		return routeChecks[r]
+	}
+	return ""
+}
+
+type severity int
+
+const (
+	severityNone severity = iota
+	severityLow
+	severityMedium
+	severityHigh
+	severityCritical
+)
+
+var severities = []string{
+	"None",
+	"Low",
+	"Medium",
+	"High",
+	"Critical",
+}
+
+func (s severity) String() string {
+	if s >= 0 && int(s) < len(severities) {
+		return severities[s]
+	}
+	return ""
+}
+
+type auditFinding int
+
+const (
+	auditFindingWorldOpenAdminPort auditFinding = iota
+	auditFindingAllTraffic
+	auditFindingAllPorts
+	auditFindingIpv6WorldAccess
+	auditFindingBroadCidr
+	auditFindingWorldOpen
+)
+
+var auditFindings = []string{
+	"WorldOpenAdminPort",
+	"AllTraffic",
+	"AllPorts",
+	"Ipv6WorldAccess",
+	"BroadCidr",
+	"WorldOpen",
+}
+
+func (a auditFinding) String() string {
+	if a >= 0 && int(a) < len(auditFindings) {
+		return auditFindings[a]
+	}
+	return ""
+}
+
+type redundancy int
+
+const (
+	redundancyDuplicate redundancy = iota
+	redundancySubsumed
+	redundancyMergeable
+)
+
+var redundancies = []string{
+	"Duplicate",
+	"Subsumed",
+	"Mergeable",
+}
+
+func (r redundancy) String() string {
+	if r >= 0 && int(r) < len(redundancies) {
+		return redundancies[r]
+	}
+	return ""
+}
+
+type reachCheck int
+
+const (
+	reachCheckSourceSecurityGroup reachCheck = iota
+	reachCheckSourceNetworkAcl
+	reachCheckSourceRouteTable
+	reachCheckPeeringConnection
+	reachCheckTransitGateway
+	reachCheckNatGateway
+	reachCheckPublicAddress
+	reachCheckDestinationRouteTable
+	reachCheckDestinationNetworkAcl
+	reachCheckDestinationSecurityGroup
+	reachCheckDestinationNetworkAclReturn
+	reachCheckSourceNetworkAclReturn
+)
+
+var reachChecks = []string{
+	"SourceSecurityGroup",
+	"SourceNetworkAcl",
+	"SourceRouteTable",
+	"PeeringConnection",
+	"TransitGateway",
+	"NatGateway",
+	"PublicAddress",
+	"DestinationRouteTable",
+	"DestinationNetworkAcl",
+	"DestinationSecurityGroup",
+	"DestinationNetworkAclReturn",
+	"SourceNetworkAclReturn",
+}
+
+func (r reachCheck) String() string {
+	if r >= 0 && int(r) < len(reachChecks) {
+		return reachChecks[r]
+	}
+	return ""
+}
+
+type reachAction int
+
+const (
+	reachActionAllow reachAction = iota
+	reachActionDeny
+	reachActionSkip
+)
+
+var reachActions = []string{
+	"Allow",
+	"Deny",
+	"Skip",
+}
+
+func (r reachAction) String() string {
+	if r >= 0 && int(r) < len(reachActions) {
+		return reachActions[r]
+	}
+	return ""
+}
+
+type cidrOverlap int
+
+const (
+	cidrOverlapEqual cidrOverlap = iota
+	cidrOverlapContains
+	cidrOverlapWithin
+)
+
+var cidrOverlaps = []string{
+	"Equal",
+	"Contains",
+	"Within",
+}
+
+func (c cidrOverlap) String() string {
+	if c >= 0 && int(c) < len(cidrOverlaps) {
+		return cidrOverlaps[c]
+	}
+	return ""
+}
@@ -3,6 +3,9 @@ package ec2
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,11 +13,68 @@ import (
 	"github.com/google/go-jsonnet"
 )
 
-func ParseEc2Filters(s string) ([]types.Filter, error) {
-	s, err := toJSON(toBracket(s))
+const tagFilterPrefix = "tag:"
+
+// ParseEc2Filters parses a filter passed either as jsonnet code such as
+// '{name: "key", values: ["value1", "value2"]}' or as shorthand such as
+// 'key=value1,value2'. Variables are bound as jsonnet external variables.
+func ParseEc2Filters(s string, vars map[string]string) ([]types.Filter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		filter, err := parseShorthand(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse value passed in filter: %w", err)
+		}
+		return []types.Filter{filter}, nil
+	}
+	j, err := toJSON(vars, func(vm *jsonnet.VM) (string, error) {
+		return vm.EvaluateAnonymousSnippet("", toBracket(s))
+	})
 	if err != nil {
 		return nil, err
 	}
+	return unmarshalFilters(j)
+}
+
+// ParseEc2FilterFile parses a jsonnet file evaluating to a filter or an array of filters.
+// Variables are bound as jsonnet external variables.
+func ParseEc2FilterFile(path string, vars map[string]string) ([]types.Filter, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("cannot read filter file: %w", err)
+	}
+	j, err := toJSON(vars, func(vm *jsonnet.VM) (string, error) {
+		vm.Importer(&jsonnet.FileImporter{})
+		return vm.EvaluateFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalFilters(toBracket(strings.TrimSpace(j)))
+}
+
+func parseShorthand(s string) (types.Filter, error) {
+	name, values, ok := strings.Cut(s, "=")
+	if !ok {
+		return types.Filter{}, fmt.Errorf("missing \"=\" in filter string: %s", s)
+	}
+	filter := types.Filter{
+		Name: aws.String(strings.TrimSpace(name)),
+	}
+	for _, v := range strings.Split(values, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			filter.Values = append(filter.Values, v)
+		}
+	}
+	if err := validateFilters([]types.Filter{filter}); err != nil {
+		return types.Filter{}, err
+	}
+	return filter, nil
+}
+
+func unmarshalFilters(s string) ([]types.Filter, error) {
 	var filters []types.Filter
 	if err := json.Unmarshal([]byte(s), &filters); err != nil {
 		return nil, fmt.Errorf("cannot unmarshal value passed in filter: %w", err)
@@ -32,9 +92,12 @@ func toBracket(s string) string {
 	return s
 }
 
-func toJSON(s string) (string, error) {
+func toJSON(vars map[string]string, evaluate func(vm *jsonnet.VM) (string, error)) (string, error) {
 	vm := jsonnet.MakeVM()
-	j, err := vm.EvaluateAnonymousSnippet("", s)
+	for k, v := range vars {
+		vm.ExtVar(k, v)
+	}
+	j, err := evaluate(vm)
 	if err != nil {
 		return "", fmt.Errorf("cannot convert to json from jsonnet code: %w", err)
 	}
@@ -52,3 +115,98 @@ func validateFilters(filters []types.Filter) error {
 	}
 	return nil
 }
+
+// validateFilterNames checks the filter names against the ones accepted by the API,
+// suggesting the closest name for a typo.
+func validateFilterNames(api string, names []string, filters []types.Filter) error {
+	for _, filter := range filters {
+		name := aws.ToString(filter.Name)
+		if strings.HasPrefix(name, tagFilterPrefix) && len(name) > len(tagFilterPrefix) {
+			continue
+		}
+		if slices.Contains(names, name) {
+			continue
+		}
+		if s := suggest(name, names); s != "" {
+			return fmt.Errorf("invalid filter name for %s: %s: did you mean \"%s\"?", api, name, s)
+		}
+		return fmt.Errorf("invalid filter name for %s: %s: see https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_%s.html", api, name, api)
+	}
+	return nil
+}
+
+// matchEc2Filters reports whether the item matches every filter as the describe APIs match them: any of the values
+// returned for the filter name matches any of the filter values, where "*" and "?" are wildcards.
+func matchEc2Filters(filters []types.Filter, values func(name string) []string) bool {
+	for _, filter := range filters {
+		if !slices.ContainsFunc(values(aws.ToString(filter.Name)), func(v string) bool {
+			return slices.ContainsFunc(filter.Values, func(pattern string) bool {
+				return matchEc2FilterValue(pattern, v)
+			})
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchEc2FilterValue(pattern, s string) bool {
+	expr := strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern))
+	ok, err := regexp.MatchString("^"+expr+"$", s)
+	return err == nil && ok
+}
+
+// tagFilterValues returns the values of the tags matched by the tag:<key>, tag-key and tag-value filters,
+// or false for other filter names.
+func tagFilterValues(tags []types.Tag, name string) ([]string, bool) {
+	key, isTag := strings.CutPrefix(name, tagFilterPrefix)
+	if !isTag && name != "tag-key" && name != "tag-value" {
+		return nil, false
+	}
+	var res []string
+	for _, t := range tags {
+		switch {
+		case isTag:
+			if aws.ToString(t.Key) == key {
+				res = append(res, aws.ToString(t.Value))
+			}
+		case name == "tag-key":
+			res = append(res, aws.ToString(t.Key))
+		default:
+			res = append(res, aws.ToString(t.Value))
+		}
+	}
+	return res, true
+}
+
+// suggest returns the candidate closest to s by edit distance, or empty if none is close enough.
+func suggest(s string, candidates []string) string {
+	best, limit := "", len(s)/3+2
+	for _, c := range candidates {
+		if d := distance(s, c); d < limit {
+			best, limit = c, d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,417 @@
+package ec2
+
+// Filter names accepted by each describe API, besides "tag:<key>".
+// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/Using_Filtering.html
+
+var instanceFilterNames = []string{
+	"affinity",
+	"architecture",
+	"availability-zone",
+	"block-device-mapping.attach-time",
+	"block-device-mapping.delete-on-termination",
+	"block-device-mapping.device-name",
+	"block-device-mapping.status",
+	"block-device-mapping.volume-id",
+	"boot-mode",
+	"capacity-reservation-id",
+	"capacity-reservation-specification.capacity-reservation-preference",
+	"capacity-reservation-specification.capacity-reservation-target.capacity-reservation-id",
+	"capacity-reservation-specification.capacity-reservation-target.capacity-reservation-resource-group-arn",
+	"client-token",
+	"current-instance-boot-mode",
+	"dns-name",
+	"ebs-optimized",
+	"ena-support",
+	"enclave-options.enabled",
+	"hibernation-options.configured",
+	"host-id",
+	"hypervisor",
+	"iam-instance-profile.arn",
+	"iam-instance-profile.id",
+	"image-id",
+	"instance-id",
+	"instance-lifecycle",
+	"instance-state-code",
+	"instance-state-name",
+	"instance-type",
+	"instance.group-id",
+	"instance.group-name",
+	"ip-address",
+	"ipv6-address",
+	"kernel-id",
+	"key-name",
+	"launch-index",
+	"launch-time",
+	"maintenance-options.auto-recovery",
+	"metadata-options.http-endpoint",
+	"metadata-options.http-protocol-ipv4",
+	"metadata-options.http-protocol-ipv6",
+	"metadata-options.http-put-response-hop-limit",
+	"metadata-options.http-tokens",
+	"metadata-options.instance-metadata-tags",
+	"monitoring-state",
+	"network-interface.addresses.association.allocation-id",
+	"network-interface.addresses.association.association-id",
+	"network-interface.addresses.association.carrier-ip",
+	"network-interface.addresses.association.customer-owned-ip",
+	"network-interface.addresses.association.ip-owner-id",
+	"network-interface.addresses.association.public-dns-name",
+	"network-interface.addresses.association.public-ip",
+	"network-interface.addresses.primary",
+	"network-interface.addresses.private-dns-name",
+	"network-interface.addresses.private-ip-address",
+	"network-interface.association.allocation-id",
+	"network-interface.association.association-id",
+	"network-interface.association.carrier-ip",
+	"network-interface.association.customer-owned-ip",
+	"network-interface.association.ip-owner-id",
+	"network-interface.association.public-dns-name",
+	"network-interface.association.public-ip",
+	"network-interface.attachment.attach-time",
+	"network-interface.attachment.attachment-id",
+	"network-interface.attachment.delete-on-termination",
+	"network-interface.attachment.device-index",
+	"network-interface.attachment.instance-id",
+	"network-interface.attachment.instance-owner-id",
+	"network-interface.attachment.network-card-index",
+	"network-interface.attachment.status",
+	"network-interface.availability-zone",
+	"network-interface.deny-all-igw-traffic",
+	"network-interface.description",
+	"network-interface.group-id",
+	"network-interface.group-name",
+	"network-interface.ipv4-prefixes.ipv4-prefix",
+	"network-interface.ipv6-address",
+	"network-interface.ipv6-addresses.ipv6-address",
+	"network-interface.ipv6-addresses.is-primary-ipv6",
+	"network-interface.ipv6-native",
+	"network-interface.ipv6-prefixes.ipv6-prefix",
+	"network-interface.mac-address",
+	"network-interface.network-interface-id",
+	"network-interface.outpost-arn",
+	"network-interface.owner-id",
+	"network-interface.private-dns-name",
+	"network-interface.private-ip-address",
+	"network-interface.public-dns-name",
+	"network-interface.requester-id",
+	"network-interface.requester-managed",
+	"network-interface.source-dest-check",
+	"network-interface.status",
+	"network-interface.subnet-id",
+	"network-interface.tag-key",
+	"network-interface.tag-value",
+	"network-interface.vpc-id",
+	"outpost-arn",
+	"owner-id",
+	"placement-group-name",
+	"placement-partition-number",
+	"platform",
+	"platform-details",
+	"private-dns-name",
+	"private-dns-name-options.enable-resource-name-dns-a-record",
+	"private-dns-name-options.enable-resource-name-dns-aaaa-record",
+	"private-dns-name-options.hostname-type",
+	"private-ip-address",
+	"product-code",
+	"product-code.type",
+	"ramdisk-id",
+	"reason",
+	"requester-id",
+	"reservation-id",
+	"root-device-name",
+	"root-device-type",
+	"source-dest-check",
+	"spot-instance-request-id",
+	"state-reason-code",
+	"state-reason-message",
+	"subnet-id",
+	"tag-key",
+	"tag-value",
+	"tenancy",
+	"tpm-support",
+	"usage-operation",
+	"usage-operation-update-time",
+	"virtualization-type",
+	"vpc-id",
+}
+
+var imageFilterNames = []string{
+	"architecture",
+	"block-device-mapping.delete-on-termination",
+	"block-device-mapping.device-name",
+	"block-device-mapping.encrypted",
+	"block-device-mapping.snapshot-id",
+	"block-device-mapping.volume-size",
+	"block-device-mapping.volume-type",
+	"creation-date",
+	"description",
+	"ena-support",
+	"hypervisor",
+	"image-allowed",
+	"image-id",
+	"image-type",
+	"is-public",
+	"kernel-id",
+	"manifest-location",
+	"name",
+	"owner-alias",
+	"owner-id",
+	"platform",
+	"platform-details",
+	"product-code",
+	"product-code.type",
+	"ramdisk-id",
+	"root-device-name",
+	"root-device-type",
+	"source-image-id",
+	"source-image-region",
+	"source-instance-id",
+	"sriov-net-support",
+	"state",
+	"state-reason-code",
+	"state-reason-message",
+	"tag-key",
+	"tag-value",
+	"usage-operation",
+	"virtualization-type",
+}
+
+var securityGroupFilterNames = []string{
+	"description",
+	"egress.ip-permission.cidr",
+	"egress.ip-permission.from-port",
+	"egress.ip-permission.group-id",
+	"egress.ip-permission.group-name",
+	"egress.ip-permission.ipv6-cidr",
+	"egress.ip-permission.prefix-list-id",
+	"egress.ip-permission.protocol",
+	"egress.ip-permission.to-port",
+	"egress.ip-permission.user-id",
+	"group-id",
+	"group-name",
+	"ip-permission.cidr",
+	"ip-permission.from-port",
+	"ip-permission.group-id",
+	"ip-permission.group-name",
+	"ip-permission.ipv6-cidr",
+	"ip-permission.prefix-list-id",
+	"ip-permission.protocol",
+	"ip-permission.to-port",
+	"ip-permission.user-id",
+	"owner-id",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var vpcFilterNames = []string{
+	"cidr",
+	"cidr-block-association.association-id",
+	"cidr-block-association.cidr-block",
+	"cidr-block-association.state",
+	"dhcp-options-id",
+	"ipv6-cidr-block-association.association-id",
+	"ipv6-cidr-block-association.ipv6-cidr-block",
+	"ipv6-cidr-block-association.ipv6-pool",
+	"ipv6-cidr-block-association.state",
+	"is-default",
+	"owner-id",
+	"state",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var subnetFilterNames = []string{
+	"availability-zone",
+	"availability-zone-id",
+	"available-ip-address-count",
+	"cidr-block",
+	"customer-owned-ipv4-pool",
+	"default-for-az",
+	"enable-dns64",
+	"enable-lni-at-device-index",
+	"ipv6-cidr-block-association.association-id",
+	"ipv6-cidr-block-association.ipv6-cidr-block",
+	"ipv6-cidr-block-association.state",
+	"ipv6-native",
+	"map-customer-owned-ip-on-launch",
+	"map-public-ip-on-launch",
+	"outpost-arn",
+	"owner-id",
+	"private-dns-name-options-on-launch.enable-resource-name-dns-a-record",
+	"private-dns-name-options-on-launch.enable-resource-name-dns-aaaa-record",
+	"private-dns-name-options-on-launch.hostname-type",
+	"state",
+	"subnet-arn",
+	"subnet-id",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var routeTableFilterNames = []string{
+	"association.gateway-id",
+	"association.main",
+	"association.route-table-association-id",
+	"association.route-table-id",
+	"association.subnet-id",
+	"owner-id",
+	"route-table-id",
+	"route.destination-cidr-block",
+	"route.destination-ipv6-cidr-block",
+	"route.destination-prefix-list-id",
+	"route.egress-only-internet-gateway-id",
+	"route.gateway-id",
+	"route.instance-id",
+	"route.nat-gateway-id",
+	"route.origin",
+	"route.state",
+	"route.transit-gateway-id",
+	"route.vpc-peering-connection-id",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var networkInterfaceFilterNames = []string{
+	"addresses.association.owner-id",
+	"addresses.association.public-ip",
+	"addresses.primary",
+	"addresses.private-ip-address",
+	"association.allocation-id",
+	"association.association-id",
+	"association.ip-owner-id",
+	"association.public-dns-name",
+	"association.public-ip",
+	"attachment.attach-time",
+	"attachment.attachment-id",
+	"attachment.delete-on-termination",
+	"attachment.device-index",
+	"attachment.instance-id",
+	"attachment.instance-owner-id",
+	"attachment.status",
+	"availability-zone",
+	"description",
+	"group-id",
+	"group-name",
+	"interface-type",
+	"ipv6-address",
+	"ipv6-addresses.ipv6-address",
+	"mac-address",
+	"network-interface-id",
+	"owner-id",
+	"private-dns-name",
+	"private-ip-address",
+	"requester-id",
+	"requester-managed",
+	"source-dest-check",
+	"status",
+	"subnet-id",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var volumeFilterNames = []string{
+	"attachment.attach-time",
+	"attachment.delete-on-termination",
+	"attachment.device",
+	"attachment.instance-id",
+	"attachment.status",
+	"availability-zone",
+	"create-time",
+	"encrypted",
+	"fast-restored",
+	"multi-attach-enabled",
+	"size",
+	"snapshot-id",
+	"status",
+	"tag-key",
+	"tag-value",
+	"volume-id",
+	"volume-type",
+}
+
+var snapshotFilterNames = []string{
+	"description",
+	"encrypted",
+	"owner-alias",
+	"owner-id",
+	"progress",
+	"snapshot-id",
+	"start-time",
+	"status",
+	"storage-tier",
+	"tag-key",
+	"tag-value",
+	"volume-id",
+	"volume-size",
+}
+
+var addressFilterNames = []string{
+	"allocation-id",
+	"association-id",
+	"domain",
+	"instance-id",
+	"network-border-group",
+	"network-interface-id",
+	"network-interface-owner-id",
+	"private-ip-address",
+	"public-ip",
+	"tag-key",
+	"tag-value",
+}
+
+var networkAclFilterNames = []string{
+	"association.association-id",
+	"association.network-acl-id",
+	"association.subnet-id",
+	"default",
+	"entry.cidr",
+	"entry.egress",
+	"entry.icmp.code",
+	"entry.icmp.type",
+	"entry.ipv6-cidr",
+	"entry.port-range.from",
+	"entry.port-range.to",
+	"entry.protocol",
+	"entry.rule-action",
+	"entry.rule-number",
+	"network-acl-id",
+	"owner-id",
+	"tag-key",
+	"tag-value",
+	"vpc-id",
+}
+
+var transitGatewayFilterNames = []string{
+	"options.amazon-side-asn",
+	"options.association-default-route-table-id",
+	"options.auto-accept-shared-attachments",
+	"options.default-route-table-association",
+	"options.default-route-table-propagation",
+	"options.dns-support",
+	"options.propagation-default-route-table-id",
+	"options.vpn-ecmp-support",
+	"owner-id",
+	"state",
+	"tag-key",
+	"tag-value",
+	"transit-gateway-id",
+}
+
+var vpcPeeringConnectionFilterNames = []string{
+	"accepter-vpc-info.cidr-block",
+	"accepter-vpc-info.owner-id",
+	"accepter-vpc-info.vpc-id",
+	"expiration-time",
+	"requester-vpc-info.cidr-block",
+	"requester-vpc-info.owner-id",
+	"requester-vpc-info.vpc-id",
+	"status-code",
+	"status-message",
+	"tag-key",
+	"tag-value",
+	"vpc-peering-connection-id",
+}
@@ -0,0 +1,214 @@
+package ec2
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Network holds the resources of a region deciding where traffic flows.
+type Network struct {
+	Vpcs               map[string]types.Vpc
+	Subnets            map[string]types.Subnet
+	RouteTables        map[string]types.RouteTable
+	NetworkAcls        map[string]types.NetworkAcl
+	SecurityGroups     map[string]types.SecurityGroup
+	PrefixLists        map[string]types.PrefixList
+	ManagedPrefixLists map[string]types.ManagedPrefixList
+}
+
+func FetchNetwork(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (*Network, error) {
+	nw := &Network{}
+	eg, ctx := errgroup.WithContext(ctx)
+	fetch := func(f func(ctx context.Context) error) {
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			return f(ctx)
+		})
+	}
+	fetch(func(ctx context.Context) (err error) {
+		nw.Vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.Subnets, err = client.FetchSubnets(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.RouteTables, err = client.FetchRouteTables(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.NetworkAcls, err = client.FetchNetworkAcls(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.SecurityGroups, err = client.FetchSecurityGroups(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.PrefixLists, err = client.FetchPrefixLists(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.ManagedPrefixLists, err = client.FetchManagedPrefixLists(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return nw, nil
+}
+
+// internetRoute returns the active route of the route table sending the default route of the family to an internet gateway.
+func internetRoute(rtb types.RouteTable, ipv6 bool) (types.Route, bool) {
+	for _, rt := range rtb.Routes {
+		if rt.State != types.RouteStateActive || !strings.HasPrefix(aws.ToString(rt.GatewayId), "igw-") {
+			continue
+		}
+		if !ipv6 && aws.ToString(rt.DestinationCidrBlock) == "0.0.0.0/0" || ipv6 && aws.ToString(rt.DestinationIpv6CidrBlock) == "::/0" {
+			return rt, true
+		}
+	}
+	return types.Route{}, false
+}
+
+// defaultNetworkAclRuleNumber is the number of the rule denying what no other rule matches.
+const defaultNetworkAclRuleNumber int32 = 32767
+
+type portRange struct {
+	From int32
+	To   int32
+}
+
+var allPorts = portRange{From: 0, To: 65535}
+
+// ephemeralPorts are the client ports of return traffic that stateless network ACLs must allow.
+var ephemeralPorts = portRange{From: 1024, To: 65535}
+
+// aclDecision is the action applied by a network ACL rule to a part of a port range.
+type aclDecision struct {
+	Ports      portRange
+	Allow      bool
+	RuleNumber int32
+}
+
+// evaluateEc2NetworkAcl walks the rules of the direction in rule number order and returns the action applied
+// to each part of the port range for the protocol and peer. A rule applies when its CIDR block contains the peer,
+// so a peer of 0.0.0.0/0 only matches rules open to the world. Ports are ignored for protocols without them.
+func evaluateEc2NetworkAcl(acl types.NetworkAcl, egress bool, protocol string, ports portRange, peer netip.Prefix) []aclDecision {
+	entries := slices.Clone(acl.Entries)
+	slices.SortFunc(entries, func(a, b types.NetworkAclEntry) int {
+		return int(aws.ToInt32(a.RuleNumber)) - int(aws.ToInt32(b.RuleNumber))
+	})
+	protocol = getEc2ProtocolName(protocol)
+	hasPorts := isEc2PortRangeProtocol(protocol)
+	if !hasPorts {
+		ports = portRange{}
+	}
+	remaining := []portRange{ports}
+	var res []aclDecision
+	for _, entry := range entries {
+		if len(remaining) == 0 {
+			break
+		}
+		if aws.ToBool(entry.Egress) != egress {
+			continue
+		}
+		cidr := aws.ToString(entry.CidrBlock)
+		if entry.Ipv6CidrBlock != nil {
+			cidr = aws.ToString(entry.Ipv6CidrBlock)
+		}
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil || !containsEc2Prefix(p, peer) {
+			continue
+		}
+		entryProtocol := getEc2ProtocolName(aws.ToString(entry.Protocol))
+		if entryProtocol != "-1" && entryProtocol != protocol {
+			continue
+		}
+		r := allPorts
+		if hasPorts && entryProtocol != "-1" && entry.PortRange != nil {
+			r = portRange{From: aws.ToInt32(entry.PortRange.From), To: aws.ToInt32(entry.PortRange.To)}
+		}
+		if !hasPorts {
+			r = portRange{}
+		}
+		var next []portRange
+		for _, rem := range remaining {
+			from, to := max(rem.From, r.From), min(rem.To, r.To)
+			if from > to {
+				next = append(next, rem)
+				continue
+			}
+			res = append(res, aclDecision{
+				Ports:      portRange{From: from, To: to},
+				Allow:      entry.RuleAction == types.RuleActionAllow,
+				RuleNumber: aws.ToInt32(entry.RuleNumber),
+			})
+			if rem.From < from {
+				next = append(next, portRange{From: rem.From, To: from - 1})
+			}
+			if to < rem.To {
+				next = append(next, portRange{From: to + 1, To: rem.To})
+			}
+		}
+		remaining = next
+	}
+	for _, rem := range remaining {
+		res = append(res, aclDecision{
+			Ports:      rem,
+			RuleNumber: defaultNetworkAclRuleNumber,
+		})
+	}
+	slices.SortFunc(res, func(a, b aclDecision) int {
+		return int(a.Ports.From) - int(b.Ports.From)
+	})
+	return res
+}
+
+// allowsEc2NetworkAcl reports whether any part of the port range is allowed, with the first rule allowing it
+// or the rule denying the range.
+func allowsEc2NetworkAcl(acl types.NetworkAcl, egress bool, protocol string, ports portRange, peer netip.Prefix) (bool, int32) {
+	decisions := evaluateEc2NetworkAcl(acl, egress, protocol, ports, peer)
+	for _, d := range decisions {
+		if d.Allow {
+			return true, d.RuleNumber
+		}
+	}
+	return false, decisions[0].RuleNumber
+}
+
+// containsEc2Prefix reports whether the block p contains every address of the block q of the same family.
+func containsEc2Prefix(p, q netip.Prefix) bool {
+	return p.Addr().Is4() == q.Addr().Is4() && p.Bits() <= q.Bits() && p.Contains(q.Addr())
+}
+
+// findEc2Route returns the route with the longest prefix containing the CIDR block, or nil.
+// Routes to prefix lists are not considered.
+func findEc2Route(rtb types.RouteTable, cidr netip.Prefix) *types.Route {
+	var best *types.Route
+	bits := -1
+	for i, rt := range rtb.Routes {
+		d := aws.ToString(rt.DestinationCidrBlock)
+		if cidr.Addr().Is6() {
+			d = aws.ToString(rt.DestinationIpv6CidrBlock)
+		}
+		prefix, err := netip.ParsePrefix(d)
+		if err != nil {
+			continue
+		}
+		if containsEc2Prefix(prefix, cidr) && prefix.Bits() > bits {
+			best, bits = &rtb.Routes[i], prefix.Bits()
+		}
+	}
+	return best
+}
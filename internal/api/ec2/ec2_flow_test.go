@@ -0,0 +1,253 @@
+package ec2
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func aclEntry(number int32, egress bool, action types.RuleAction, protocol, cidr string, ports *portRange) types.NetworkAclEntry {
+	entry := types.NetworkAclEntry{
+		RuleNumber: aws.Int32(number),
+		Egress:     aws.Bool(egress),
+		RuleAction: action,
+		Protocol:   aws.String(protocol),
+	}
+	if prefix := netip.MustParsePrefix(cidr); prefix.Addr().Is6() {
+		entry.Ipv6CidrBlock = aws.String(cidr)
+	} else {
+		entry.CidrBlock = aws.String(cidr)
+	}
+	if ports != nil {
+		entry.PortRange = &types.PortRange{From: aws.Int32(ports.From), To: aws.Int32(ports.To)}
+	}
+	return entry
+}
+
+func Test_evaluateEc2NetworkAcl(t *testing.T) {
+	allow, deny := types.RuleActionAllow, types.RuleActionDeny
+	defaults := []types.NetworkAclEntry{
+		aclEntry(defaultNetworkAclRuleNumber, false, deny, "-1", "0.0.0.0/0", nil),
+		aclEntry(defaultNetworkAclRuleNumber, false, deny, "-1", "::/0", nil),
+	}
+	type args struct {
+		entries  []types.NetworkAclEntry
+		egress   bool
+		protocol string
+		ports    portRange
+		peer     string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []aclDecision
+	}{
+		{
+			name: "deny before allow",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(200, false, allow, "6", "0.0.0.0/0", &portRange{From: 22, To: 22}),
+					aclEntry(100, false, deny, "6", "203.0.113.0/24", &portRange{From: 22, To: 22}),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 22, To: 22},
+				peer:     "203.0.113.5/32",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 22, To: 22}, Allow: false, RuleNumber: 100},
+			},
+		},
+		{
+			name: "allow before deny",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, false, allow, "6", "0.0.0.0/0", &portRange{From: 22, To: 22}),
+					aclEntry(200, false, deny, "-1", "0.0.0.0/0", nil),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 22, To: 22},
+				peer:     "198.51.100.1/32",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 22, To: 22}, Allow: true, RuleNumber: 100},
+			},
+		},
+		{
+			name: "partial port overlap",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, false, deny, "6", "0.0.0.0/0", &portRange{From: 1000, To: 2000}),
+					aclEntry(110, false, allow, "6", "0.0.0.0/0", &portRange{From: 0, To: 1500}),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 500, To: 3000},
+				peer:     "0.0.0.0/0",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 500, To: 999}, Allow: true, RuleNumber: 110},
+				{Ports: portRange{From: 1000, To: 2000}, Allow: false, RuleNumber: 100},
+				{Ports: portRange{From: 2001, To: 3000}, Allow: false, RuleNumber: defaultNetworkAclRuleNumber},
+			},
+		},
+		{
+			name: "other direction and protocol ignored",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, true, allow, "-1", "0.0.0.0/0", nil),
+					aclEntry(110, false, allow, "17", "0.0.0.0/0", &portRange{From: 53, To: 53}),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 53, To: 53},
+				peer:     "10.0.0.1/32",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 53, To: 53}, Allow: false, RuleNumber: defaultNetworkAclRuleNumber},
+			},
+		},
+		{
+			name: "rule narrower than peer not applied",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, false, allow, "6", "10.0.0.0/8", &portRange{From: 443, To: 443}),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 443, To: 443},
+				peer:     "0.0.0.0/0",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 443, To: 443}, Allow: false, RuleNumber: defaultNetworkAclRuleNumber},
+			},
+		},
+		{
+			name: "ipv6 entry",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, false, allow, "-1", "0.0.0.0/0", nil),
+					aclEntry(101, false, deny, "6", "2001:db8::/32", &portRange{From: 22, To: 22}),
+					aclEntry(102, false, allow, "6", "::/0", &portRange{From: 0, To: 65535}),
+				}, defaults...),
+				protocol: "tcp",
+				ports:    portRange{From: 22, To: 22},
+				peer:     "2001:db8::1/128",
+			},
+			want: []aclDecision{
+				{Ports: portRange{From: 22, To: 22}, Allow: false, RuleNumber: 101},
+			},
+		},
+		{
+			name: "icmp without ports",
+			args: args{
+				entries: append([]types.NetworkAclEntry{
+					aclEntry(100, false, allow, "1", "0.0.0.0/0", &portRange{From: -1, To: -1}),
+				}, defaults...),
+				protocol: "icmp",
+				ports:    portRange{From: 8, To: 8},
+				peer:     "192.0.2.1/32",
+			},
+			want: []aclDecision{
+				{Ports: portRange{}, Allow: true, RuleNumber: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := types.NetworkAcl{Entries: tt.args.entries}
+			got := evaluateEc2NetworkAcl(acl, tt.args.egress, tt.args.protocol, tt.args.ports, netip.MustParsePrefix(tt.args.peer))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluateEc2NetworkAcl() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_allowsEc2NetworkAcl(t *testing.T) {
+	acl := types.NetworkAcl{
+		Entries: []types.NetworkAclEntry{
+			aclEntry(100, false, types.RuleActionDeny, "6", "0.0.0.0/0", &portRange{From: 0, To: 1023}),
+			aclEntry(110, false, types.RuleActionAllow, "6", "0.0.0.0/0", &portRange{From: 1024, To: 65535}),
+			aclEntry(defaultNetworkAclRuleNumber, false, types.RuleActionDeny, "-1", "0.0.0.0/0", nil),
+		},
+	}
+	tests := []struct {
+		name    string
+		ports   portRange
+		allowed bool
+		rule    int32
+	}{
+		{name: "denied range", ports: portRange{From: 80, To: 80}, allowed: false, rule: 100},
+		{name: "allowed range", ports: portRange{From: 8080, To: 8080}, allowed: true, rule: 110},
+		{name: "part allowed", ports: portRange{From: 1000, To: 65535}, allowed: true, rule: 110},
+		{name: "ephemeral ports", ports: ephemeralPorts, allowed: true, rule: 110},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, rule := allowsEc2NetworkAcl(acl, false, "tcp", tt.ports, netip.MustParsePrefix("192.0.2.1/32"))
+			if allowed != tt.allowed || rule != tt.rule {
+				t.Errorf("allowsEc2NetworkAcl() = %v, %d, want %v, %d", allowed, rule, tt.allowed, tt.rule)
+			}
+		})
+	}
+}
+
+func Test_containsEc2Prefix(t *testing.T) {
+	tests := []struct {
+		p, q string
+		want bool
+	}{
+		{p: "0.0.0.0/0", q: "0.0.0.0/0", want: true},
+		{p: "0.0.0.0/0", q: "10.0.0.1/32", want: true},
+		{p: "10.0.0.0/8", q: "0.0.0.0/0", want: false},
+		{p: "10.0.0.0/16", q: "10.0.255.0/24", want: true},
+		{p: "10.0.0.0/16", q: "10.1.0.0/24", want: false},
+		{p: "0.0.0.0/0", q: "2001:db8::/32", want: false},
+		{p: "::/0", q: "2001:db8::1/128", want: true},
+		{p: "2001:db8::/32", q: "2001:db9::/32", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.p+" "+tt.q, func(t *testing.T) {
+			if got := containsEc2Prefix(netip.MustParsePrefix(tt.p), netip.MustParsePrefix(tt.q)); got != tt.want {
+				t.Errorf("containsEc2Prefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findEc2Route(t *testing.T) {
+	rtb := types.RouteTable{
+		Routes: []types.Route{
+			{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+			{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")},
+			{DestinationCidrBlock: aws.String("10.1.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")},
+			{DestinationCidrBlock: aws.String("10.1.2.0/24"), TransitGatewayId: aws.String("tgw-1")},
+			{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-1")},
+			{DestinationIpv6CidrBlock: aws.String("2001:db8::/56"), GatewayId: aws.String("local")},
+			{DestinationPrefixListId: aws.String("pl-1"), GatewayId: aws.String("vpce-1")},
+		},
+	}
+	tests := []struct {
+		cidr string
+		want int
+	}{
+		{cidr: "10.0.1.5/32", want: 0},
+		{cidr: "10.1.2.0/24", want: 3},
+		{cidr: "10.1.3.0/24", want: 2},
+		{cidr: "10.1.0.0/15", want: 1},
+		{cidr: "0.0.0.0/0", want: 1},
+		{cidr: "2001:db8::1/128", want: 5},
+		{cidr: "2001:db9::1/128", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got := findEc2Route(rtb, netip.MustParsePrefix(tt.cidr))
+			if got != &rtb.Routes[tt.want] {
+				t.Errorf("findEc2Route() = %v, want %v", got, rtb.Routes[tt.want])
+			}
+		})
+	}
+	if got := findEc2Route(types.RouteTable{Routes: rtb.Routes[:1]}, netip.MustParsePrefix("192.0.2.0/24")); got != nil {
+		t.Errorf("findEc2Route() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,340 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Gateways holds the resources of a region that routes can target.
+type Gateways struct {
+	InternetGateways             map[string]types.InternetGateway
+	EgressOnlyInternetGateways   map[string]types.EgressOnlyInternetGateway
+	NatGateways                  map[string]types.NatGateway
+	VpnGateways                  map[string]types.VpnGateway
+	VpcEndpoints                 map[string]types.VpcEndpoint
+	VpcPeeringConnections        map[string]types.VpcPeeringConnection
+	TransitGateways              map[string]types.TransitGateway
+	TransitGatewayVpcAttachments map[string]types.TransitGatewayVpcAttachment
+	Instances                    map[string]types.Instance
+	NetworkInterfaces            map[string]types.NetworkInterface
+}
+
+func FetchGateways(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (*Gateways, error) {
+	g := &Gateways{}
+	eg, ctx := errgroup.WithContext(ctx)
+	fetch := func(f func(ctx context.Context) error) {
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			return f(ctx)
+		})
+	}
+	fetch(func(ctx context.Context) (err error) {
+		g.InternetGateways, err = client.FetchInternetGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.EgressOnlyInternetGateways, err = client.FetchEgressOnlyInternetGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.NatGateways, err = client.FetchNatGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.VpnGateways, err = client.FetchVpnGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.VpcEndpoints, err = client.FetchVpcEndpoints(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.VpcPeeringConnections, err = client.FetchVpcPeeringConnections(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.TransitGateways, err = client.FetchTransitGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.TransitGatewayVpcAttachments, err = client.FetchTransitGatewayVpcAttachments(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.Instances, err = client.FetchInstances(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		g.NetworkInterfaces, err = client.FetchNetworkInterfaces(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// routeTarget returns the name and state of the resource the route targets, and whether it was found.
+// Both are empty for the local route and for resources that no longer exist.
+func (g *Gateways) routeTarget(rt types.Route, n *Namer) (string, string, bool) {
+	if g == nil {
+		return "", "", false
+	}
+	switch {
+	case rt.GatewayId != nil:
+		id := aws.ToString(rt.GatewayId)
+		if igw, ok := g.InternetGateways[id]; ok {
+			return n.name(igw.Tags, igw.InternetGatewayId), getEc2InternetGatewayState(igw.Attachments), true
+		}
+		if vgw, ok := g.VpnGateways[id]; ok {
+			return n.name(vgw.Tags, vgw.VpnGatewayId), string(vgw.State), true
+		}
+		if vpce, ok := g.VpcEndpoints[id]; ok {
+			return n.name(vpce.Tags, vpce.ServiceName, vpce.VpcEndpointId), string(vpce.State), true
+		}
+	case rt.NatGatewayId != nil:
+		if nat, ok := g.NatGateways[aws.ToString(rt.NatGatewayId)]; ok {
+			return n.name(nat.Tags, nat.NatGatewayId), string(nat.State), true
+		}
+	case rt.VpcPeeringConnectionId != nil:
+		if pcx, ok := g.VpcPeeringConnections[aws.ToString(rt.VpcPeeringConnectionId)]; ok {
+			return n.name(pcx.Tags, pcx.VpcPeeringConnectionId), getEc2VpcPeeringConnectionState(pcx), true
+		}
+	case rt.TransitGatewayId != nil:
+		if tgw, ok := g.TransitGateways[aws.ToString(rt.TransitGatewayId)]; ok {
+			return n.name(tgw.Tags, tgw.TransitGatewayId), string(tgw.State), true
+		}
+	case rt.EgressOnlyInternetGatewayId != nil:
+		if eigw, ok := g.EgressOnlyInternetGateways[aws.ToString(rt.EgressOnlyInternetGatewayId)]; ok {
+			return n.name(eigw.Tags, eigw.EgressOnlyInternetGatewayId), getEc2InternetGatewayState(eigw.Attachments), true
+		}
+	case rt.InstanceId != nil:
+		if i, ok := g.Instances[aws.ToString(rt.InstanceId)]; ok {
+			return n.name(i.Tags, i.PrivateDnsName, i.InstanceId), string(i.State.Name), true
+		}
+	case rt.NetworkInterfaceId != nil:
+		if eni, ok := g.NetworkInterfaces[aws.ToString(rt.NetworkInterfaceId)]; ok {
+			return n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId), string(eni.Status), true
+		}
+	}
+	return "", "", false
+}
+
+// tracksRouteTarget reports whether the target of the route is a resource held by Gateways.
+// The local route and carrier gateways, local gateways and core networks are not tracked.
+func tracksRouteTarget(rt types.Route) bool {
+	switch {
+	case rt.GatewayId != nil:
+		return aws.ToString(rt.GatewayId) != "local"
+	case rt.NatGatewayId != nil,
+		rt.VpcPeeringConnectionId != nil,
+		rt.TransitGatewayId != nil,
+		rt.EgressOnlyInternetGatewayId != nil,
+		rt.InstanceId != nil,
+		rt.NetworkInterfaceId != nil:
+		return true
+	}
+	return false
+}
+
+type VpcGatewayInfo struct {
+	VpcId            string
+	VpcName          string
+	GatewayType      string
+	GatewayId        string
+	GatewayName      string
+	State            string
+	SubnetId         string
+	SubnetName       string
+	AvailabilityZone string
+	PublicIp         string
+	PeerId           string
+	PeerOwnerId      string
+	PeerRegion       string
+	Region           string
+}
+
+func FetchDataForVpcGatewayInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Subnet, *Gateways, error) {
+	var sbns map[string]types.Subnet
+	var gws *Gateways
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		sbns, err = client.FetchSubnets(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		gws, err = FetchGateways(ctx, l, client, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return sbns, gws, nil
+}
+
+// GetVpcGatewayInfo sends the VPCs with their internet, egress-only internet, NAT and VPN gateways,
+// VPC endpoints, peering connections and transit gateway attachments.
+// Peering connections and transit gateway attachments show the peer VPC or the transit gateway as the peer.
+func GetVpcGatewayInfo(ich chan<- VpcGatewayInfo, vpcs []types.Vpc, region string, sbns map[string]types.Subnet, gws *Gateways, n *Namer) {
+	for _, vpc := range vpcs {
+		vpcId := aws.ToString(vpc.VpcId)
+		obj := VpcGatewayInfo{
+			VpcId:   vpcId,
+			VpcName: n.name(vpc.Tags, vpc.VpcId),
+			Region:  region,
+		}
+		withSubnet := func(row VpcGatewayInfo, subnetId string) VpcGatewayInfo {
+			row.SubnetId = subnetId
+			if sbn, ok := sbns[subnetId]; ok {
+				row.SubnetName = n.name(sbn.Tags, sbn.SubnetId)
+				row.AvailabilityZone = aws.ToString(sbn.AvailabilityZone)
+			}
+			return row
+		}
+		for _, igw := range gws.InternetGateways {
+			for _, a := range igw.Attachments {
+				if aws.ToString(a.VpcId) != vpcId {
+					continue
+				}
+				row := obj
+				row.GatewayType = targetTypeInternetGateway.String()
+				row.GatewayId = aws.ToString(igw.InternetGatewayId)
+				row.GatewayName = n.name(igw.Tags, igw.InternetGatewayId)
+				row.State = string(a.State)
+				ich <- row
+			}
+		}
+		for _, eigw := range gws.EgressOnlyInternetGateways {
+			for _, a := range eigw.Attachments {
+				if aws.ToString(a.VpcId) != vpcId {
+					continue
+				}
+				row := obj
+				row.GatewayType = targetTypeEgressOnlyInternetGateway.String()
+				row.GatewayId = aws.ToString(eigw.EgressOnlyInternetGatewayId)
+				row.GatewayName = n.name(eigw.Tags, eigw.EgressOnlyInternetGatewayId)
+				row.State = string(a.State)
+				ich <- row
+			}
+		}
+		for _, nat := range gws.NatGateways {
+			if aws.ToString(nat.VpcId) != vpcId || nat.State == types.NatGatewayStateDeleted {
+				continue
+			}
+			row := withSubnet(obj, aws.ToString(nat.SubnetId))
+			row.GatewayType = targetTypeNatGateway.String()
+			row.GatewayId = aws.ToString(nat.NatGatewayId)
+			row.GatewayName = n.name(nat.Tags, nat.NatGatewayId)
+			row.State = string(nat.State)
+			if len(nat.NatGatewayAddresses) == 0 {
+				ich <- row
+				continue
+			}
+			for _, addr := range nat.NatGatewayAddresses {
+				row.PublicIp = aws.ToString(addr.PublicIp)
+				ich <- row
+			}
+		}
+		for _, vgw := range gws.VpnGateways {
+			for _, a := range vgw.VpcAttachments {
+				if aws.ToString(a.VpcId) != vpcId {
+					continue
+				}
+				row := obj
+				row.GatewayType = targetTypeVpnGateway.String()
+				row.GatewayId = aws.ToString(vgw.VpnGatewayId)
+				row.GatewayName = n.name(vgw.Tags, vgw.VpnGatewayId)
+				row.State = string(a.State)
+				ich <- row
+			}
+		}
+		for _, vpce := range gws.VpcEndpoints {
+			if aws.ToString(vpce.VpcId) != vpcId {
+				continue
+			}
+			row := obj
+			row.GatewayType = targetTypeVpcEndpoint.String()
+			row.GatewayId = aws.ToString(vpce.VpcEndpointId)
+			row.GatewayName = n.name(vpce.Tags, vpce.ServiceName, vpce.VpcEndpointId)
+			row.State = string(vpce.State)
+			if len(vpce.SubnetIds) == 0 {
+				ich <- row
+				continue
+			}
+			for _, id := range vpce.SubnetIds {
+				ich <- withSubnet(row, id)
+			}
+		}
+		for _, pcx := range gws.VpcPeeringConnections {
+			if pcx.RequesterVpcInfo == nil || pcx.AccepterVpcInfo == nil {
+				continue
+			}
+			var peer *types.VpcPeeringConnectionVpcInfo
+			switch vpcId {
+			case aws.ToString(pcx.RequesterVpcInfo.VpcId):
+				peer = pcx.AccepterVpcInfo
+			case aws.ToString(pcx.AccepterVpcInfo.VpcId):
+				peer = pcx.RequesterVpcInfo
+			default:
+				continue
+			}
+			row := obj
+			row.GatewayType = targetTypeVpcPeeringConnection.String()
+			row.GatewayId = aws.ToString(pcx.VpcPeeringConnectionId)
+			row.GatewayName = n.name(pcx.Tags, pcx.VpcPeeringConnectionId)
+			row.State = getEc2VpcPeeringConnectionState(pcx)
+			row.PeerId = aws.ToString(peer.VpcId)
+			row.PeerOwnerId = aws.ToString(peer.OwnerId)
+			row.PeerRegion = aws.ToString(peer.Region)
+			ich <- row
+		}
+		for _, attach := range gws.TransitGatewayVpcAttachments {
+			if aws.ToString(attach.VpcId) != vpcId || attach.State == types.TransitGatewayAttachmentStateDeleted {
+				continue
+			}
+			row := obj
+			row.GatewayType = targetTypeTransitGateway.String()
+			row.GatewayId = aws.ToString(attach.TransitGatewayAttachmentId)
+			row.GatewayName = n.name(attach.Tags, attach.TransitGatewayAttachmentId)
+			row.State = string(attach.State)
+			row.PeerId = aws.ToString(attach.TransitGatewayId)
+			if tgw, ok := gws.TransitGateways[row.PeerId]; ok {
+				row.PeerOwnerId = aws.ToString(tgw.OwnerId)
+			}
+			row.PeerRegion = region
+			if len(attach.SubnetIds) == 0 {
+				ich <- row
+				continue
+			}
+			for _, id := range attach.SubnetIds {
+				ich <- withSubnet(row, id)
+			}
+		}
+	}
+}
+
+// getEc2InternetGatewayState returns the state of the first attachment, or "detached".
+func getEc2InternetGatewayState(attachments []types.InternetGatewayAttachment) string {
+	if len(attachments) == 0 {
+		return "detached"
+	}
+	return string(attachments[0].State)
+}
+
+func getEc2VpcPeeringConnectionState(pcx types.VpcPeeringConnection) string {
+	if pcx.Status == nil {
+		return ""
+	}
+	return string(pcx.Status.Code)
+}
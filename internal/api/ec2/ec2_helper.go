@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -90,6 +91,32 @@ func fetchEc2Images(ctx context.Context, client *ec2.Client, region string) (map
 	return res, nil
 }
 
+func fetchEc2OwnedImages(ctx context.Context, client *ec2.Client, region string) (map[string]types.Image, error) {
+	var token *string
+	res := make(map[string]types.Image)
+	for {
+		input := &ec2.DescribeImagesInput{
+			NextToken: token,
+			Owners:    []string{"self"},
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeImages(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range o.Images {
+			res[aws.ToString(i.ImageId)] = i
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
 func fetchEc2Snapshots(ctx context.Context, client *ec2.Client, region string) (map[string]types.Snapshot, error) {
 	var token *string
 	res := make(map[string]types.Snapshot)
@@ -316,6 +343,348 @@ func fetchEc2DhcpOptions(ctx context.Context, client *ec2.Client, region string)
 	return res, nil
 }
 
+func fetchEc2NetworkInterfaces(ctx context.Context, client *ec2.Client, region string) (map[string]types.NetworkInterface, error) {
+	var token *string
+	res := make(map[string]types.NetworkInterface)
+	for {
+		input := &ec2.DescribeNetworkInterfacesInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeNetworkInterfaces(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, eni := range o.NetworkInterfaces {
+			res[aws.ToString(eni.NetworkInterfaceId)] = eni
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2NatGateways(ctx context.Context, client *ec2.Client, region string) (map[string]types.NatGateway, error) {
+	var token *string
+	res := make(map[string]types.NatGateway)
+	for {
+		input := &ec2.DescribeNatGatewaysInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeNatGateways(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, nat := range o.NatGateways {
+			res[aws.ToString(nat.NatGatewayId)] = nat
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2NetworkAcls(ctx context.Context, client *ec2.Client, region string) (map[string]types.NetworkAcl, error) {
+	var token *string
+	res := make(map[string]types.NetworkAcl)
+	for {
+		input := &ec2.DescribeNetworkAclsInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeNetworkAcls(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, acl := range o.NetworkAcls {
+			res[aws.ToString(acl.NetworkAclId)] = acl
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2InternetGateways(ctx context.Context, client *ec2.Client, region string) (map[string]types.InternetGateway, error) {
+	var token *string
+	res := make(map[string]types.InternetGateway)
+	for {
+		input := &ec2.DescribeInternetGatewaysInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeInternetGateways(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, igw := range o.InternetGateways {
+			res[aws.ToString(igw.InternetGatewayId)] = igw
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2EgressOnlyInternetGateways(ctx context.Context, client *ec2.Client, region string) (map[string]types.EgressOnlyInternetGateway, error) {
+	var token *string
+	res := make(map[string]types.EgressOnlyInternetGateway)
+	for {
+		input := &ec2.DescribeEgressOnlyInternetGatewaysInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeEgressOnlyInternetGateways(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, eigw := range o.EgressOnlyInternetGateways {
+			res[aws.ToString(eigw.EgressOnlyInternetGatewayId)] = eigw
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2VpcEndpoints(ctx context.Context, client *ec2.Client, region string) (map[string]types.VpcEndpoint, error) {
+	var token *string
+	res := make(map[string]types.VpcEndpoint)
+	for {
+		input := &ec2.DescribeVpcEndpointsInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeVpcEndpoints(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, vpce := range o.VpcEndpoints {
+			res[aws.ToString(vpce.VpcEndpointId)] = vpce
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2VpcPeeringConnections(ctx context.Context, client *ec2.Client, region string) (map[string]types.VpcPeeringConnection, error) {
+	var token *string
+	res := make(map[string]types.VpcPeeringConnection)
+	for {
+		input := &ec2.DescribeVpcPeeringConnectionsInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeVpcPeeringConnections(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, pcx := range o.VpcPeeringConnections {
+			res[aws.ToString(pcx.VpcPeeringConnectionId)] = pcx
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2TransitGateways(ctx context.Context, client *ec2.Client, region string) (map[string]types.TransitGateway, error) {
+	var token *string
+	res := make(map[string]types.TransitGateway)
+	for {
+		input := &ec2.DescribeTransitGatewaysInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeTransitGateways(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, tgw := range o.TransitGateways {
+			res[aws.ToString(tgw.TransitGatewayId)] = tgw
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2TransitGatewayVpcAttachments(ctx context.Context, client *ec2.Client, region string) (map[string]types.TransitGatewayVpcAttachment, error) {
+	var token *string
+	res := make(map[string]types.TransitGatewayVpcAttachment)
+	for {
+		input := &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeTransitGatewayVpcAttachments(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, attach := range o.TransitGatewayVpcAttachments {
+			res[aws.ToString(attach.TransitGatewayAttachmentId)] = attach
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2VpnGateways(ctx context.Context, client *ec2.Client, region string) (map[string]types.VpnGateway, error) {
+	res := make(map[string]types.VpnGateway)
+	input := &ec2.DescribeVpnGatewaysInput{}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeVpnGateways(ctx, input, opt)
+	if err != nil {
+		return nil, err
+	}
+	for _, vgw := range o.VpnGateways {
+		res[aws.ToString(vgw.VpnGatewayId)] = vgw
+	}
+	return res, nil
+}
+
+func fetchEc2TransitGatewayAttachments(ctx context.Context, client *ec2.Client, region string) (map[string]types.TransitGatewayAttachment, error) {
+	var token *string
+	res := make(map[string]types.TransitGatewayAttachment)
+	for {
+		input := &ec2.DescribeTransitGatewayAttachmentsInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeTransitGatewayAttachments(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, attach := range o.TransitGatewayAttachments {
+			res[aws.ToString(attach.TransitGatewayAttachmentId)] = attach
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2TransitGatewayRouteTables(ctx context.Context, client *ec2.Client, region string) (map[string]types.TransitGatewayRouteTable, error) {
+	var token *string
+	res := make(map[string]types.TransitGatewayRouteTable)
+	for {
+		input := &ec2.DescribeTransitGatewayRouteTablesInput{
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeTransitGatewayRouteTables(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, rtb := range o.TransitGatewayRouteTables {
+			res[aws.ToString(rtb.TransitGatewayRouteTableId)] = rtb
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func fetchEc2VpnConnections(ctx context.Context, client *ec2.Client, region string) (map[string]types.VpnConnection, error) {
+	res := make(map[string]types.VpnConnection)
+	input := &ec2.DescribeVpnConnectionsInput{}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeVpnConnections(ctx, input, opt)
+	if err != nil {
+		return nil, err
+	}
+	for _, vpn := range o.VpnConnections {
+		res[aws.ToString(vpn.VpnConnectionId)] = vpn
+	}
+	return res, nil
+}
+
+func fetchEc2Tags(ctx context.Context, client *ec2.Client, region string, keys []string) (map[string]map[string]string, error) {
+	var token *string
+	res := make(map[string]map[string]string)
+	for {
+		input := &ec2.DescribeTagsInput{
+			NextToken: token,
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("key"),
+					Values: keys,
+				},
+			},
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeTags(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range o.Tags {
+			id := aws.ToString(t.ResourceId)
+			if res[id] == nil {
+				res[id] = make(map[string]string)
+			}
+			res[id][aws.ToString(t.Key)] = aws.ToString(t.Value)
+		}
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
 func getEc2VpcDnsSupport(ctx context.Context, client *ec2.Client, region string, id *string) (bool, error) {
 	input := &ec2.DescribeVpcAttributeInput{
 		VpcId:     id,
@@ -346,13 +715,137 @@ func getEc2VpcDnsHostnames(ctx context.Context, client *ec2.Client, region strin
 	return aws.ToBool(attr.EnableDnsHostnames.Value), err
 }
 
-func getEc2NameTagValue(tags []types.Tag) string {
-	for _, t := range tags {
-		if t.Key != nil && strings.EqualFold(aws.ToString(t.Key), "Name") && t.Value != nil {
-			return *t.Value
+func getEc2SnapshotCreateVolumePermissions(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.CreateVolumePermission, error) {
+	input := &ec2.DescribeSnapshotAttributeInput{
+		SnapshotId: id,
+		Attribute:  types.SnapshotAttributeNameCreateVolumePermission,
+	}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	attr, err := client.DescribeSnapshotAttribute(ctx, input, opt)
+	if err != nil {
+		return nil, err
+	}
+	return attr.CreateVolumePermissions, nil
+}
+
+func getEc2ManagedPrefixListEntries(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.PrefixListEntry, error) {
+	var token *string
+	var res []types.PrefixListEntry
+	for {
+		input := &ec2.GetManagedPrefixListEntriesInput{
+			PrefixListId: id,
+			NextToken:    token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.GetManagedPrefixListEntries(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, o.Entries...)
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func getEc2TransitGatewayRouteTableAssociations(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.TransitGatewayRouteTableAssociation, error) {
+	var token *string
+	var res []types.TransitGatewayRouteTableAssociation
+	for {
+		input := &ec2.GetTransitGatewayRouteTableAssociationsInput{
+			TransitGatewayRouteTableId: id,
+			NextToken:                  token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.GetTransitGatewayRouteTableAssociations(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, o.Associations...)
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+func getEc2TransitGatewayRouteTablePropagations(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.TransitGatewayRouteTablePropagation, error) {
+	var token *string
+	var res []types.TransitGatewayRouteTablePropagation
+	for {
+		input := &ec2.GetTransitGatewayRouteTablePropagationsInput{
+			TransitGatewayRouteTableId: id,
+			NextToken:                  token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.GetTransitGatewayRouteTablePropagations(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, o.TransitGatewayRouteTablePropagations...)
+		token = o.NextToken
+		if token == nil {
+			break
 		}
 	}
-	return ""
+	return res, nil
+}
+
+// searchEc2TransitGatewayRoutes returns the active and blackhole routes of the route table.
+// The API requires a filter and does not paginate, returning at most 1000 routes.
+func searchEc2TransitGatewayRoutes(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.TransitGatewayRoute, error) {
+	input := &ec2.SearchTransitGatewayRoutesInput{
+		TransitGatewayRouteTableId: id,
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: []string{string(types.TransitGatewayRouteStateActive), string(types.TransitGatewayRouteStateBlackhole)},
+			},
+		},
+	}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.SearchTransitGatewayRoutes(ctx, input, opt)
+	if err != nil {
+		return nil, err
+	}
+	return o.Routes, nil
+}
+
+func getEc2StaleSecurityGroups(ctx context.Context, client *ec2.Client, region string, id *string) ([]types.StaleSecurityGroup, error) {
+	var token *string
+	var res []types.StaleSecurityGroup
+	for {
+		input := &ec2.DescribeStaleSecurityGroupsInput{
+			VpcId:     id,
+			NextToken: token,
+		}
+		opt := func(opt *ec2.Options) {
+			opt.Region = region
+		}
+		o, err := client.DescribeStaleSecurityGroups(ctx, input, opt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, o.StaleSecurityGroupSet...)
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return res, nil
 }
 
 func findEc2ImageById(id string, m map[string]types.Image) *types.Image {
@@ -398,8 +891,12 @@ func findEc2SubnetById(id string, m map[string]types.Subnet) (*types.Subnet, err
 }
 
 func findEc2RouteTableBySubnet(subnet types.Subnet, m map[string]types.RouteTable) (*types.RouteTable, error) {
-	if item, ok := m[aws.ToString(subnet.SubnetId)]; ok {
-		return &item, nil
+	for _, item := range m {
+		for _, assoc := range item.Associations {
+			if aws.ToString(assoc.SubnetId) == aws.ToString(subnet.SubnetId) {
+				return &item, nil
+			}
+		}
 	}
 	if subnet.VpcId != nil {
 		for _, item := range m {
@@ -415,6 +912,17 @@ func findEc2RouteTableBySubnet(subnet types.Subnet, m map[string]types.RouteTabl
 	return nil, fmt.Errorf("no route table found %s: ", *subnet.SubnetId)
 }
 
+func findEc2NetworkAclBySubnet(subnet types.Subnet, m map[string]types.NetworkAcl) (*types.NetworkAcl, error) {
+	for _, item := range m {
+		for _, assoc := range item.Associations {
+			if aws.ToString(assoc.SubnetId) == aws.ToString(subnet.SubnetId) {
+				return &item, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no network acl found %s: ", aws.ToString(subnet.SubnetId))
+}
+
 func findEc2PrefixListById(id string, m map[string]types.PrefixList) *types.PrefixList {
 	if item, ok := m[id]; ok {
 		return &item
@@ -466,6 +974,19 @@ func getEc2RouteDestination(rt types.Route) (string, string, error) {
 	return dtype, d, nil
 }
 
+// getEc2TransitGatewayRouteDestination returns the type and value of the destination.
+// Transit gateway routes hold both IPv4 and IPv6 CIDR blocks in the same field.
+func getEc2TransitGatewayRouteDestination(rt types.TransitGatewayRoute) (string, string) {
+	if rt.PrefixListId != nil {
+		return addressTypePrefixList.String(), aws.ToString(rt.PrefixListId)
+	}
+	d := aws.ToString(rt.DestinationCidrBlock)
+	if strings.Contains(d, ":") {
+		return addressTypeIpv6.String(), d
+	}
+	return addressTypeIpv4.String(), d
+}
+
 func getEc2RouteTarget(rt types.Route) (string, string, error) {
 	var ttype, t string
 	switch {
@@ -515,3 +1036,35 @@ func getEc2RouteTarget(rt types.Route) (string, string, error) {
 	}
 	return ttype, t, nil
 }
+
+// getEc2ProtocolName returns the name of the IP protocol number used by network ACLs,
+// which is the form security groups use for the common protocols.
+func getEc2ProtocolName(protocol string) string {
+	switch protocol {
+	case "1":
+		return "icmp"
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "58":
+		return "icmpv6"
+	default:
+		return protocol
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ageDays returns the number of whole days elapsed from t to now.
+func ageDays(t *time.Time, now time.Time) int {
+	if t == nil {
+		return 0
+	}
+	return int(now.Sub(*t).Hours() / 24)
+}
@@ -36,6 +36,25 @@ func CreateDescribeImagesInput(ids, names []string, filters []types.Filter, defa
 	return input
 }
 
+var ImageLister = &Lister[types.Image]{
+	Name:    "DescribeImages",
+	Filters: imageFilterNames,
+	List:    listImages,
+}
+
+func listImages(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Image, *string, error) {
+	input := CreateDescribeImagesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeImages(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.Images, o.NextToken, nil
+}
+
 type ImageInfo struct {
 	ImageId      string
 	ImageName    string
@@ -104,7 +123,7 @@ func FetchDataForImageBackupInfo(ctx context.Context, l *rate.Limiter, client IE
 	return snps, vols, nil
 }
 
-func GetImageBackupInfo(ich chan<- ImageBackupInfo, images []types.Image, region string, snps map[string]types.Snapshot, vols map[string]types.Volume) {
+func GetImageBackupInfo(ich chan<- ImageBackupInfo, images []types.Image, region string, snps map[string]types.Snapshot, vols map[string]types.Volume, n *Namer) {
 	for _, image := range images {
 		var bds []*types.EbsBlockDevice
 		for _, bdm := range image.BlockDeviceMappings {
@@ -119,12 +138,12 @@ func GetImageBackupInfo(ich chan<- ImageBackupInfo, images []types.Image, region
 				snp := findEc2SnapshotById(snpId, snps)
 				if snp != nil {
 					snapshotId = snpId
-					snapshotName = getEc2NameTagValue(snp.Tags)
+					snapshotName = n.name(snp.Tags, snp.SnapshotId)
 					volId := aws.ToString(snp.VolumeId)
 					vol := findEc2VolumeById(aws.ToString(snp.VolumeId), vols)
 					if vol != nil {
 						volumeId = volId
-						volumeName = getEc2NameTagValue(vol.Tags)
+						volumeName = n.name(vol.Tags, vol.VolumeId)
 					}
 				}
 				ich <- ImageBackupInfo{
@@ -2,7 +2,10 @@ package ec2
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -41,6 +44,44 @@ func CreateDescribeInstancesInput(ids, names []string, filters []types.Filter, d
 	}
 }
 
+var InstanceLister = &Lister[types.Reservation]{
+	Name:    "DescribeInstances",
+	Filters: instanceFilterNames,
+	List:    listInstances,
+	Named:   namedInstances,
+}
+
+// namedInstances narrows the reservations to the instances named by their tags as one of the names.
+func namedInstances(items []types.Reservation, n *Namer, names []string) []types.Reservation {
+	var res []types.Reservation
+	for _, r := range items {
+		var instances []types.Instance
+		for _, i := range r.Instances {
+			if slices.Contains(names, n.tagName(i.Tags)) {
+				instances = append(instances, i)
+			}
+		}
+		if len(instances) > 0 {
+			r.Instances = instances
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func listInstances(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Reservation, *string, error) {
+	input := CreateDescribeInstancesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeInstances(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.Reservations, o.NextToken, nil
+}
+
 type InstanceInfo struct {
 	InstanceId       string
 	InstanceName     string
@@ -52,12 +93,12 @@ type InstanceInfo struct {
 	AvailabilityZone string
 }
 
-func GetInstanceInfo(ich chan<- InstanceInfo, reservations []types.Reservation) {
+func GetInstanceInfo(ich chan<- InstanceInfo, reservations []types.Reservation, n *Namer) {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			ich <- InstanceInfo{
 				InstanceId:       aws.ToString(i.InstanceId),
-				InstanceName:     getEc2NameTagValue(i.Tags),
+				InstanceName:     n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
 				InstanceType:     i.InstanceType,
 				PrivateIpAddress: aws.ToString(i.PrivateIpAddress),
 				PublicIpAddress:  aws.ToString(i.PublicIpAddress),
@@ -82,6 +123,7 @@ type InstanceSecurityGroupInfo struct {
 	ToPort            int32
 	AddressType       string
 	CidrBlock         string
+	PrefixList        string
 	AvailabilityZone  string
 }
 
@@ -129,16 +171,18 @@ func FetchDataForInstanceSecurityGroupInfo(ctx context.Context, l *rate.Limiter,
 	return segs, vpcs, upls, mpls, nil
 }
 
-func GetInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, reservations []types.Reservation, region string, segs map[string]types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList) error {
+// GetInstanceSecurityGroupInfo sends the instances with the rules of their security groups.
+// Rules referencing a prefix list are expanded to its CIDR blocks when the entries are not nil.
+func GetInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, reservations []types.Reservation, region string, segs map[string]types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, n *Namer) error {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
-			name := getEc2NameTagValue(i.Tags)
+			name := n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
 			vpcId := aws.ToString(i.VpcId)
 			vpc, err := findEc2VpcById(vpcId, vpcs)
 			if err != nil {
 				return err
 			}
-			vpcName := getEc2NameTagValue(vpc.Tags)
+			vpcName := n.name(vpc.Tags, vpc.VpcId)
 			for _, seg := range i.SecurityGroups {
 				obj := InstanceSecurityGroupInfo{
 					InstanceId:        aws.ToString(i.InstanceId),
@@ -153,10 +197,10 @@ func GetInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, reservat
 				if err != nil {
 					return err
 				}
-				if err = appendToInstanceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, false, region); err != nil {
+				if err = appendToInstanceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, ples, false, region, n); err != nil {
 					return err
 				}
-				if err = appendToInstanceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, true, region); err != nil {
+				if err = appendToInstanceSecurityGroupInfo(ich, obj, *sg, vpcs, upls, mpls, ples, true, region, n); err != nil {
 					return err
 				}
 			}
@@ -165,8 +209,8 @@ func GetInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, reservat
 	return nil
 }
 
-func appendToInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, obj InstanceSecurityGroupInfo, sg types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, isEgress bool, region string) error {
-	perms, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, isEgress, region)
+func appendToInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, obj InstanceSecurityGroupInfo, sg types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, isEgress bool, region string, n *Namer) error {
+	perms, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, ples, isEgress, region, n)
 	if err != nil {
 		return err
 	}
@@ -177,11 +221,285 @@ func appendToInstanceSecurityGroupInfo(ich chan<- InstanceSecurityGroupInfo, obj
 		obj.ToPort = perm.ToPort
 		obj.AddressType = perm.AddressType
 		obj.CidrBlock = perm.CidrBlock
+		obj.PrefixList = perm.PrefixList
 		ich <- obj
 	}
 	return nil
 }
 
+type InstanceSecurityGroupAuditInfo struct {
+	InstanceId        string
+	InstanceName      string
+	VpcId             string
+	VpcName           string
+	SecurityGroupId   string
+	SecurityGroupName string
+	Severity          string
+	Finding           string
+	IpProtocol        string
+	FromPort          int32
+	ToPort            int32
+	AddressType       string
+	CidrBlock         string
+	AvailabilityZone  string
+}
+
+// GetInstanceSecurityGroupAuditInfo sends a row for each finding of the ingress rules of the security groups attached to the instances.
+// The data is fetched by FetchDataForInstanceSecurityGroupInfo.
+func GetInstanceSecurityGroupAuditInfo(ich chan<- InstanceSecurityGroupAuditInfo, reservations []types.Reservation, region string, segs map[string]types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, rules *AuditRules, n *Namer) error {
+	for _, r := range reservations {
+		for _, i := range r.Instances {
+			name := n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+			for _, seg := range i.SecurityGroups {
+				sg, err := findEc2SecurityGroupById(aws.ToString(seg.GroupId), segs)
+				if err != nil {
+					return err
+				}
+				perms, err := handleSecurityGroupPermissionsInfo(*sg, vpcs, upls, mpls, ples, false, region, n)
+				if err != nil {
+					return err
+				}
+				for _, perm := range perms {
+					for _, res := range rules.audit(perm) {
+						ich <- InstanceSecurityGroupAuditInfo{
+							InstanceId:        aws.ToString(i.InstanceId),
+							InstanceName:      name,
+							VpcId:             perm.VpcId,
+							VpcName:           perm.VpcName,
+							SecurityGroupId:   perm.SecurityGroupId,
+							SecurityGroupName: perm.SecurityGroupName,
+							Severity:          res.Severity,
+							Finding:           res.Finding,
+							IpProtocol:        perm.IpProtocol,
+							FromPort:          perm.FromPort,
+							ToPort:            perm.ToPort,
+							AddressType:       perm.AddressType,
+							CidrBlock:         perm.CidrBlock,
+							AvailabilityZone:  aws.ToString(i.Placement.AvailabilityZone),
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type InstanceSecurityGroupRedundancyInfo struct {
+	InstanceId                string
+	InstanceName              string
+	VpcId                     string
+	VpcName                   string
+	SecurityGroupId           string
+	SecurityGroupName         string
+	FlowDirection             string
+	Finding                   string
+	IpProtocol                string
+	FromPort                  int32
+	ToPort                    int32
+	AddressType               string
+	CidrBlock                 string
+	CoveringSecurityGroupId   string
+	CoveringSecurityGroupName string
+	CoveringIpProtocol        string
+	CoveringFromPort          int32
+	CoveringToPort            int32
+	CoveringCidrBlock         string
+	Suggestion                string
+	AvailabilityZone          string
+}
+
+// GetInstanceSecurityGroupRedundancyInfo sends a row for each rule made redundant by another rule
+// of any security group attached to the instance, including rules duplicated across groups.
+// The data is fetched by FetchDataForInstanceSecurityGroupInfo.
+func GetInstanceSecurityGroupRedundancyInfo(ich chan<- InstanceSecurityGroupRedundancyInfo, reservations []types.Reservation, region string, segs map[string]types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, n *Namer) error {
+	for _, r := range reservations {
+		for _, i := range r.Instances {
+			var perms []SecurityGroupPermissionsInfo
+			for _, seg := range i.SecurityGroups {
+				sg, err := findEc2SecurityGroupById(aws.ToString(seg.GroupId), segs)
+				if err != nil {
+					return err
+				}
+				p, err := handleSecurityGroupRules(*sg, vpcs, upls, mpls, region, n)
+				if err != nil {
+					return err
+				}
+				perms = append(perms, p...)
+			}
+			name := n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+			for _, rr := range findEc2RedundantRules(perms) {
+				ich <- InstanceSecurityGroupRedundancyInfo{
+					InstanceId:                aws.ToString(i.InstanceId),
+					InstanceName:              name,
+					VpcId:                     rr.Rule.VpcId,
+					VpcName:                   rr.Rule.VpcName,
+					SecurityGroupId:           rr.Rule.SecurityGroupId,
+					SecurityGroupName:         rr.Rule.SecurityGroupName,
+					FlowDirection:             rr.Rule.FlowDirection,
+					Finding:                   rr.Finding,
+					IpProtocol:                rr.Rule.IpProtocol,
+					FromPort:                  rr.Rule.FromPort,
+					ToPort:                    rr.Rule.ToPort,
+					AddressType:               rr.Rule.AddressType,
+					CidrBlock:                 rr.Rule.CidrBlock,
+					CoveringSecurityGroupId:   rr.Cover.SecurityGroupId,
+					CoveringSecurityGroupName: rr.Cover.SecurityGroupName,
+					CoveringIpProtocol:        rr.Cover.IpProtocol,
+					CoveringFromPort:          rr.Cover.FromPort,
+					CoveringToPort:            rr.Cover.ToPort,
+					CoveringCidrBlock:         rr.Cover.CidrBlock,
+					Suggestion:                rr.Suggestion,
+					AvailabilityZone:          aws.ToString(i.Placement.AvailabilityZone),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type InstanceExposureInfo struct {
+	InstanceId        string
+	InstanceName      string
+	PublicIpAddress   string
+	SecurityGroupId   string
+	SecurityGroupName string
+	IpProtocol        string
+	FromPort          int32
+	ToPort            int32
+	CidrBlock         string
+	Exposed           bool
+	Reason            string
+	AvailabilityZone  string
+}
+
+// GetInstanceExposureInfo sends the ports of the instances open to the internet by their security groups,
+// and whether the public address, the route to an internet gateway and the network ACL of the subnet
+// let the traffic through. The reason chains the checks passed, ending with the one failed if any.
+// Only the subnet of the primary network interface is checked.
+func GetInstanceExposureInfo(ich chan<- InstanceExposureInfo, reservations []types.Reservation, region string, nw *Network, ples PrefixListEntries, n *Namer) error {
+	for _, r := range reservations {
+		for _, i := range r.Instances {
+			if i.VpcId == nil || i.SubnetId == nil {
+				continue
+			}
+			sbn, err := findEc2SubnetById(aws.ToString(i.SubnetId), nw.Subnets)
+			if err != nil {
+				return err
+			}
+			rtb, err := findEc2RouteTableBySubnet(*sbn, nw.RouteTables)
+			if err != nil {
+				return err
+			}
+			acl, err := findEc2NetworkAclBySubnet(*sbn, nw.NetworkAcls)
+			if err != nil {
+				return err
+			}
+			obj := InstanceExposureInfo{
+				InstanceId:       aws.ToString(i.InstanceId),
+				InstanceName:     n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
+				AvailabilityZone: aws.ToString(i.Placement.AvailabilityZone),
+			}
+			for _, seg := range i.SecurityGroups {
+				sg, err := findEc2SecurityGroupById(aws.ToString(seg.GroupId), nw.SecurityGroups)
+				if err != nil {
+					return err
+				}
+				perms, err := handleSecurityGroupPermissionsInfo(*sg, nw.Vpcs, nw.PrefixLists, nw.ManagedPrefixLists, ples, false, region, n)
+				if err != nil {
+					return err
+				}
+				for _, perm := range perms {
+					world, err := netip.ParsePrefix(perm.CidrBlock)
+					if err != nil || world.Bits() != 0 {
+						continue
+					}
+					obj.SecurityGroupId = perm.SecurityGroupId
+					obj.SecurityGroupName = perm.SecurityGroupName
+					obj.CidrBlock = perm.CidrBlock
+					for _, row := range checkEc2Exposure(obj, i, perm, world, *rtb, *acl) {
+						ich <- row
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkEc2Exposure checks the path from the internet to a rule open to the world.
+// A rule allowing all traffic is checked for TCP and UDP on every port and for ICMP.
+func checkEc2Exposure(obj InstanceExposureInfo, i types.Instance, perm SecurityGroupPermissionsInfo, world netip.Prefix, rtb types.RouteTable, acl types.NetworkAcl) []InstanceExposureInfo {
+	ipv6 := world.Addr().Is6()
+	obj.PublicIpAddress = aws.ToString(i.PublicIpAddress)
+	family := "ipv4"
+	if ipv6 {
+		obj.PublicIpAddress = aws.ToString(i.Ipv6Address)
+		family = "ipv6"
+	}
+	protocols := []string{perm.IpProtocol}
+	if perm.IpProtocol == "-1" {
+		protocols = []string{"tcp", "udp", "icmp"}
+	}
+	var res []InstanceExposureInfo
+	for _, protocol := range protocols {
+		row := obj
+		row.IpProtocol = getEc2ProtocolName(protocol)
+		row.FromPort, row.ToPort = perm.FromPort, perm.ToPort
+		switch {
+		case perm.IpProtocol != "-1":
+		case isEc2PortRangeProtocol(row.IpProtocol):
+			row.FromPort, row.ToPort = allPorts.From, allPorts.To
+		default:
+			row.FromPort, row.ToPort = -1, -1
+		}
+		if row.PublicIpAddress == "" {
+			row.Reason = fmt.Sprintf("no public %s address", family)
+			res = append(res, row)
+			continue
+		}
+		reasons := []string{fmt.Sprintf("public ip %s", row.PublicIpAddress)}
+		rt, ok := internetRoute(rtb, ipv6)
+		if !ok {
+			row.Reason = strings.Join(append(reasons, fmt.Sprintf("no route %s to internet gateway in %s", world, aws.ToString(rtb.RouteTableId))), " > ")
+			res = append(res, row)
+			continue
+		}
+		reasons = append(reasons,
+			fmt.Sprintf("%s routes %s to %s", aws.ToString(rtb.RouteTableId), world, aws.ToString(rt.GatewayId)),
+			fmt.Sprintf("%s allows %s from %s", perm.SecurityGroupId, formatEc2Ports(row.IpProtocol, row.FromPort, row.ToPort), world),
+		)
+		aclId := aws.ToString(acl.NetworkAclId)
+		for _, d := range evaluateEc2NetworkAcl(acl, false, row.IpProtocol, portRange{From: row.FromPort, To: row.ToPort}, world) {
+			part := row
+			if isEc2PortRangeProtocol(row.IpProtocol) {
+				part.FromPort, part.ToPort = d.Ports.From, d.Ports.To
+			}
+			chain := slices.Clone(reasons)
+			if !d.Allow {
+				part.Reason = strings.Join(append(chain, fmt.Sprintf("%s inbound rule %d denies", aclId, d.RuleNumber)), " > ")
+				res = append(res, part)
+				continue
+			}
+			chain = append(chain, fmt.Sprintf("%s inbound rule %d allows", aclId, d.RuleNumber))
+			ports := portRange{}
+			if isEc2PortRangeProtocol(row.IpProtocol) {
+				ports = ephemeralPorts
+			}
+			allowed, rule := allowsEc2NetworkAcl(acl, true, row.IpProtocol, ports, world)
+			if !allowed {
+				part.Reason = strings.Join(append(chain, fmt.Sprintf("%s outbound rule %d denies return traffic", aclId, rule)), " > ")
+				res = append(res, part)
+				continue
+			}
+			part.Exposed = true
+			part.Reason = strings.Join(append(chain, fmt.Sprintf("%s outbound rule %d allows return traffic", aclId, rule)), " > ")
+			res = append(res, part)
+		}
+	}
+	return res
+}
+
 type InstanceRouteInfo struct {
 	InstanceId       string
 	InstanceName     string
@@ -196,13 +514,17 @@ type InstanceRouteInfo struct {
 	Destination      string
 	TargetType       string
 	Target           string
+	TargetName       string
+	TargetState      string
 	Region           string
+	PrefixList       string
 }
 
-func FetchDataForInstanceRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.Subnet, map[string]types.RouteTable, error) {
+func FetchDataForInstanceRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.Subnet, map[string]types.RouteTable, *Gateways, error) {
 	vpcs := make(map[string]types.Vpc)
 	sbns := make(map[string]types.Subnet)
 	rtbs := make(map[string]types.RouteTable)
+	var gws *Gateways
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
 		if err := l.Wait(ctx); err != nil {
@@ -228,13 +550,18 @@ func FetchDataForInstanceRouteInfo(ctx context.Context, l *rate.Limiter, client
 		rtbs, err = client.FetchRouteTables(ctx, region)
 		return err
 	})
+	eg.Go(func() error {
+		var err error
+		gws, err = FetchGateways(ctx, l, client, region)
+		return err
+	})
 	if err := eg.Wait(); err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	return vpcs, sbns, rtbs, nil
+	return vpcs, sbns, rtbs, gws, nil
 }
 
-func GetInstanceRouteInfo(ich chan<- InstanceRouteInfo, reservations []types.Reservation, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet, rtbs map[string]types.RouteTable) error {
+func GetInstanceRouteInfo(ich chan<- InstanceRouteInfo, reservations []types.Reservation, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet, rtbs map[string]types.RouteTable, gws *Gateways, ples PrefixListEntries, n *Namer) error {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			vpc, err := findEc2VpcById(aws.ToString(i.VpcId), vpcs)
@@ -251,15 +578,15 @@ func GetInstanceRouteInfo(ich chan<- InstanceRouteInfo, reservations []types.Res
 			}
 			obj := InstanceRouteInfo{
 				InstanceId:       aws.ToString(i.InstanceId),
-				InstanceName:     getEc2NameTagValue(i.Tags),
+				InstanceName:     n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
 				AvailabilityZone: aws.ToString(i.Placement.AvailabilityZone),
 				VpcId:            aws.ToString(i.VpcId),
-				VpcName:          getEc2NameTagValue(vpc.Tags),
+				VpcName:          n.name(vpc.Tags, vpc.VpcId),
 				SubnetId:         aws.ToString(i.SubnetId),
-				SubnetName:       getEc2NameTagValue(sbn.Tags),
+				SubnetName:       n.name(sbn.Tags, sbn.SubnetId),
 				Region:           region,
 			}
-			routes, err := handleRoutes(*rtb, vpcs, region)
+			routes, err := handleRoutes(*rtb, vpcs, gws, ples, region, n)
 			if err != nil {
 				return err
 			}
@@ -270,6 +597,9 @@ func GetInstanceRouteInfo(ich chan<- InstanceRouteInfo, reservations []types.Res
 				obj.Destination = route.Destination
 				obj.TargetType = route.TargetType
 				obj.Target = route.Target
+				obj.TargetName = route.TargetName
+				obj.TargetState = route.TargetState
+				obj.PrefixList = route.PrefixList
 				ich <- obj
 			}
 		}
@@ -291,7 +621,7 @@ type InstanceStorageInfo struct {
 	AvailabilityZone    string
 }
 
-func GetInstanceStorageInfo(ich chan<- InstanceStorageInfo, reservations []types.Reservation, vols map[string]types.Volume) {
+func GetInstanceStorageInfo(ich chan<- InstanceStorageInfo, reservations []types.Reservation, vols map[string]types.Volume, n *Namer) {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			for _, bdm := range i.BlockDeviceMappings {
@@ -300,12 +630,12 @@ func GetInstanceStorageInfo(ich chan<- InstanceStorageInfo, reservations []types
 				if vol != nil {
 					ich <- InstanceStorageInfo{
 						InstanceId:          aws.ToString(i.InstanceId),
-						InstanceName:        getEc2NameTagValue(i.Tags),
+						InstanceName:        n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
 						AvailabilityZone:    aws.ToString(i.Placement.AvailabilityZone),
 						DeviceName:          aws.ToString(bdm.DeviceName),
 						DeleteOnTermination: aws.ToBool(bdm.Ebs.DeleteOnTermination),
 						VolumeId:            volId,
-						VolumeName:          getEc2NameTagValue(vol.Tags),
+						VolumeName:          n.name(vol.Tags, vol.VolumeId),
 						VolumeType:          vol.VolumeType,
 						VolumeSize:          aws.ToInt32(vol.Size),
 						IOPS:                aws.ToInt32(vol.Iops),
@@ -366,7 +696,7 @@ func FetchDataForInstanceBackupInfo(ctx context.Context, l *rate.Limiter, client
 	return imgs, snps, vols, nil
 }
 
-func GetInstanceBackupInfo(ich chan InstanceBackupInfo, reservations []types.Reservation, imgs map[string]types.Image, snps map[string]types.Snapshot, vols map[string]types.Volume) {
+func GetInstanceBackupInfo(ich chan<- InstanceBackupInfo, reservations []types.Reservation, imgs map[string]types.Image, snps map[string]types.Snapshot, vols map[string]types.Volume, n *Namer) {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			var imageId, imageName, imageOwner string
@@ -384,17 +714,17 @@ func GetInstanceBackupInfo(ich chan InstanceBackupInfo, reservations []types.Res
 					vol := findEc2VolumeById(volId, vols)
 					if vol != nil {
 						volumeId = volId
-						volumeName = getEc2NameTagValue(vol.Tags)
+						volumeName = n.name(vol.Tags, vol.VolumeId)
 						snpId := aws.ToString(vol.SnapshotId)
 						snp := findEc2SnapshotById(snpId, snps)
 						if snp != nil {
 							snapshotId = snpId
-							snapshotName = getEc2NameTagValue(snp.Tags)
+							snapshotName = n.name(snp.Tags, snp.SnapshotId)
 						}
 					}
 					ich <- InstanceBackupInfo{
 						InstanceId:          aws.ToString(i.InstanceId),
-						InstanceName:        getEc2NameTagValue(i.Tags),
+						InstanceName:        n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
 						AvailabilityZone:    aws.ToString(i.Placement.AvailabilityZone),
 						ImageId:             imageId,
 						ImageName:           imageName,
@@ -419,7 +749,7 @@ type InstanceLoadBalancerInfo struct {
 	AttachedTG       []string
 }
 
-func FetchDataForInstanceLoadBalancerInfo(ctx context.Context, l *rate.Limiter, cfg *aws.Config, region string, ids, names []string) ([]string, map[string][]string, map[string][]string, error) {
+func FetchDataForInstanceLoadBalancerInfo(ctx context.Context, l *rate.Limiter, elbClient elb.IElbClient, elbv2Client elbv2.IElbClient, region string, ids, names []string) ([]string, map[string][]string, map[string][]string, error) {
 	var sv1, sv2 []string
 	var mv1, mv2 map[string][]string
 	eg, ctx := errgroup.WithContext(ctx)
@@ -428,8 +758,7 @@ func FetchDataForInstanceLoadBalancerInfo(ctx context.Context, l *rate.Limiter,
 			return err
 		}
 		var err error
-		client := elb.NewElbClient(cfg)
-		sv1, mv1, err = client.FetchTargets(ctx, region, true)
+		sv1, mv1, err = elbClient.FetchTargets(ctx, region, true)
 		return err
 	})
 	eg.Go(func() error {
@@ -437,8 +766,7 @@ func FetchDataForInstanceLoadBalancerInfo(ctx context.Context, l *rate.Limiter,
 			return err
 		}
 		var err error
-		client := elbv2.NewElbClient(cfg)
-		sv2, mv2, err = client.FetchTargets(ctx, region, "instance", true)
+		sv2, mv2, err = elbv2Client.FetchTargets(ctx, region, "instance", true)
 		return err
 	})
 	if err := eg.Wait(); err != nil {
@@ -452,7 +780,7 @@ func FetchDataForInstanceLoadBalancerInfo(ctx context.Context, l *rate.Limiter,
 	return ids, mv1, mv2, nil
 }
 
-func GetInstanceLoadBalancerInfo(ich chan<- InstanceLoadBalancerInfo, reservations []types.Reservation, idmv1, idmv2 map[string][]string) error {
+func GetInstanceLoadBalancerInfo(ich chan<- InstanceLoadBalancerInfo, reservations []types.Reservation, idmv1, idmv2 map[string][]string, n *Namer) error {
 	for _, r := range reservations {
 		for _, i := range r.Instances {
 			id := aws.ToString(i.InstanceId)
@@ -469,7 +797,7 @@ func GetInstanceLoadBalancerInfo(ich chan<- InstanceLoadBalancerInfo, reservatio
 			}
 			ich <- InstanceLoadBalancerInfo{
 				InstanceId:       aws.ToString(i.InstanceId),
-				InstanceName:     getEc2NameTagValue(i.Tags),
+				InstanceName:     n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
 				AvailabilityZone: aws.ToString(i.Placement.AvailabilityZone),
 				AttachedLB:       lbs,
 				AttachedTG:       tgs,
@@ -0,0 +1,68 @@
+package ec2
+
+import (
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// DefaultNameTags are the tag keys names are read from unless set.
+var DefaultNameTags = []string{"Name"}
+
+// Namer resolves the name of a resource: the value of the first of the tag keys present,
+// then a resource attribute such as the DNS name of an instance, then the resource id.
+// Tag keys are case-sensitive, as in the tag filters of the describe APIs.
+type Namer struct {
+	Tags []string
+}
+
+func (n *Namer) name(tags []types.Tag, fallbacks ...*string) string {
+	if v := n.tagName(tags); v != "" {
+		return v
+	}
+	for _, f := range fallbacks {
+		if v := aws.ToString(f); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (n *Namer) keys() []string {
+	if n != nil && len(n.Tags) > 0 {
+		return n.Tags
+	}
+	return DefaultNameTags
+}
+
+// tagName returns the value of the first of the tag keys present, or an empty string.
+func (n *Namer) tagName(tags []types.Tag) string {
+	for _, key := range n.keys() {
+		for _, t := range tags {
+			if aws.ToString(t.Key) == key && aws.ToString(t.Value) != "" {
+				return aws.ToString(t.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// readsNameTag reports whether names are read from the Name tag only, as matched by the Name tag filter of the APIs.
+func (n *Namer) readsNameTag() bool {
+	keys := n.keys()
+	return len(keys) == 1 && keys[0] == "Name"
+}
+
+// namedBy returns a Lister.Named for the resources whose tags are returned by the function.
+func namedBy[I any](tags func(item I) []types.Tag) func(items []I, n *Namer, names []string) []I {
+	return func(items []I, n *Namer, names []string) []I {
+		var res []I
+		for _, item := range items {
+			if slices.Contains(names, n.tagName(tags(item))) {
+				res = append(res, item)
+			}
+		}
+		return res
+	}
+}
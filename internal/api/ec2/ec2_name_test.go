@@ -0,0 +1,39 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestNamer_tagName(t *testing.T) {
+	tags := []types.Tag{
+		{Key: aws.String("name"), Value: aws.String("lower")},
+		{Key: aws.String("Name"), Value: aws.String("upper")},
+		{Key: aws.String("Alias"), Value: aws.String("")},
+	}
+	tests := []struct {
+		name     string
+		keys     []string
+		want     string
+		nameOnly bool
+	}{
+		{name: "default", keys: nil, want: "upper", nameOnly: true},
+		{name: "Name", keys: []string{"Name"}, want: "upper", nameOnly: true},
+		{name: "lower case name", keys: []string{"name"}, want: "lower", nameOnly: false},
+		{name: "empty value skipped", keys: []string{"Alias", "Name"}, want: "upper", nameOnly: false},
+		{name: "missing", keys: []string{"NAME"}, want: "", nameOnly: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &Namer{Tags: tt.keys}
+			if got := n.tagName(tags); got != tt.want {
+				t.Errorf("tagName() = %q, want %q", got, tt.want)
+			}
+			if got := n.readsNameTag(); got != tt.nameOnly {
+				t.Errorf("readsNameTag() = %v, want %v", got, tt.nameOnly)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeNetworkAclsInput(ids, names []string, filters []types.Filter, defaultFilter bool) *ec2.DescribeNetworkAclsInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("network-acl-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	if defaultFilter {
+		f = append(f, types.Filter{
+			Name:   aws.String("default"),
+			Values: []string{"false"},
+		})
+	}
+	return &ec2.DescribeNetworkAclsInput{
+		Filters: f,
+	}
+}
+
+var NetworkAclLister = &Lister[types.NetworkAcl]{
+	Name:    "DescribeNetworkAcls",
+	Filters: networkAclFilterNames,
+	List:    listNetworkAcls,
+	Named: namedBy(func(item types.NetworkAcl) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listNetworkAcls(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.NetworkAcl, *string, error) {
+	input := CreateDescribeNetworkAclsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeNetworkAcls(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.NetworkAcls, o.NextToken, nil
+}
+
+type NetworkAclInfo struct {
+	NetworkAclId     string
+	NetworkAclName   string
+	VpcId            string
+	VpcName          string
+	IsDefault        bool
+	EntryCount       int
+	AssociationCount int
+	OwnerId          string
+	Region           string
+}
+
+func GetNetworkAclInfo(ich chan<- NetworkAclInfo, acls []types.NetworkAcl, region string, vpcs map[string]types.Vpc, n *Namer) error {
+	for _, acl := range acls {
+		vpcId := aws.ToString(acl.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		ich <- NetworkAclInfo{
+			NetworkAclId:     aws.ToString(acl.NetworkAclId),
+			NetworkAclName:   n.name(acl.Tags, acl.NetworkAclId),
+			VpcId:            vpcId,
+			VpcName:          n.name(vpc.Tags, vpc.VpcId),
+			IsDefault:        aws.ToBool(acl.IsDefault),
+			EntryCount:       len(acl.Entries),
+			AssociationCount: len(acl.Associations),
+			OwnerId:          aws.ToString(acl.OwnerId),
+			Region:           region,
+		}
+	}
+	return nil
+}
+
+type NetworkAclEntryInfo struct {
+	NetworkAclId   string
+	NetworkAclName string
+	VpcId          string
+	VpcName        string
+	FlowDirection  string
+	RuleNumber     int32
+	RuleAction     types.RuleAction
+	IpProtocol     string
+	FromPort       int32
+	ToPort         int32
+	AddressType    string
+	CidrBlock      string
+	Region         string
+}
+
+func GetNetworkAclEntryInfo(ich chan<- NetworkAclEntryInfo, acls []types.NetworkAcl, region string, vpcs map[string]types.Vpc, n *Namer) error {
+	for _, acl := range acls {
+		entries, err := handleNetworkAclEntries(acl, vpcs, region, n)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			ich <- entry
+		}
+	}
+	return nil
+}
+
+// handleNetworkAclEntries converts the entries of the network ACL into rows shaped like
+// security group permissions. The rule numbered 32767 is the default rule, shown as "*" in the console.
+func handleNetworkAclEntries(acl types.NetworkAcl, vpcs map[string]types.Vpc, region string, n *Namer) ([]NetworkAclEntryInfo, error) {
+	var info []NetworkAclEntryInfo
+	vpcId := aws.ToString(acl.VpcId)
+	vpc, err := findEc2VpcById(vpcId, vpcs)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range acl.Entries {
+		obj := NetworkAclEntryInfo{
+			NetworkAclId:   aws.ToString(acl.NetworkAclId),
+			NetworkAclName: n.name(acl.Tags, acl.NetworkAclId),
+			VpcId:          vpcId,
+			VpcName:        n.name(vpc.Tags, vpc.VpcId),
+			FlowDirection:  Ingress.String(),
+			RuleNumber:     aws.ToInt32(entry.RuleNumber),
+			RuleAction:     entry.RuleAction,
+			IpProtocol:     getEc2ProtocolName(aws.ToString(entry.Protocol)),
+			Region:         region,
+		}
+		if aws.ToBool(entry.Egress) {
+			obj.FlowDirection = Egress.String()
+		}
+		switch {
+		case entry.PortRange != nil:
+			obj.FromPort = aws.ToInt32(entry.PortRange.From)
+			obj.ToPort = aws.ToInt32(entry.PortRange.To)
+		case entry.IcmpTypeCode != nil:
+			// Same as security groups, the ICMP type and code are held in the port columns.
+			obj.FromPort = aws.ToInt32(entry.IcmpTypeCode.Type)
+			obj.ToPort = aws.ToInt32(entry.IcmpTypeCode.Code)
+		}
+		if entry.Ipv6CidrBlock != nil {
+			obj.AddressType = addressTypeIpv6.String()
+			obj.CidrBlock = aws.ToString(entry.Ipv6CidrBlock)
+		} else {
+			obj.AddressType = addressTypeIpv4.String()
+			obj.CidrBlock = aws.ToString(entry.CidrBlock)
+		}
+		info = append(info, obj)
+	}
+	return info, nil
+}
+
+type NetworkAclAssociationInfo struct {
+	NetworkAclId     string
+	NetworkAclName   string
+	VpcId            string
+	VpcName          string
+	IsDefault        bool
+	SubnetId         string
+	SubnetName       string
+	AvailabilityZone string
+	Region           string
+}
+
+func FetchDataForNetworkAclAssociationInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.Subnet, error) {
+	return FetchDataForRouteTableAssociationInfo(ctx, l, client, region)
+}
+
+func GetNetworkAclAssociationInfo(ich chan<- NetworkAclAssociationInfo, acls []types.NetworkAcl, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet, n *Namer) error {
+	for _, acl := range acls {
+		vpcId := aws.ToString(acl.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		obj := NetworkAclAssociationInfo{
+			NetworkAclId:   aws.ToString(acl.NetworkAclId),
+			NetworkAclName: n.name(acl.Tags, acl.NetworkAclId),
+			VpcId:          vpcId,
+			VpcName:        n.name(vpc.Tags, vpc.VpcId),
+			IsDefault:      aws.ToBool(acl.IsDefault),
+			Region:         region,
+		}
+		if len(acl.Associations) == 0 {
+			ich <- obj
+			continue
+		}
+		for _, assoc := range acl.Associations {
+			row := obj
+			row.SubnetId = aws.ToString(assoc.SubnetId)
+			sbn, err := findEc2SubnetById(row.SubnetId, sbns)
+			if err != nil {
+				return err
+			}
+			row.SubnetName = n.name(sbn.Tags, sbn.SubnetId)
+			row.AvailabilityZone = aws.ToString(sbn.AvailabilityZone)
+			ich <- row
+		}
+	}
+	return nil
+}
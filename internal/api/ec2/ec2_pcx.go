@@ -0,0 +1,335 @@
+package ec2
+
+import (
+	"cmp"
+	"context"
+	"net/netip"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeVpcPeeringConnectionsInput(ids, names []string, filters []types.Filter, _ bool) *ec2.DescribeVpcPeeringConnectionsInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("vpc-peering-connection-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	return &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: f,
+	}
+}
+
+var VpcPeeringConnectionLister = &Lister[types.VpcPeeringConnection]{
+	Name:    "DescribeVpcPeeringConnections",
+	Filters: vpcPeeringConnectionFilterNames,
+	List:    listVpcPeeringConnections,
+	Named: namedBy(func(item types.VpcPeeringConnection) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listVpcPeeringConnections(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.VpcPeeringConnection, *string, error) {
+	input := CreateDescribeVpcPeeringConnectionsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeVpcPeeringConnections(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.VpcPeeringConnections, o.NextToken, nil
+}
+
+type VpcPeeringConnectionInfo struct {
+	VpcPeeringConnectionId   string
+	VpcPeeringConnectionName string
+	Status                   string
+	RequesterVpcId           string
+	RequesterVpcName         string
+	RequesterOwnerId         string
+	RequesterRegion          string
+	RequesterCidrBlock       string
+	AccepterVpcId            string
+	AccepterVpcName          string
+	AccepterOwnerId          string
+	AccepterRegion           string
+	AccepterCidrBlock        string
+	Region                   string
+}
+
+// GetVpcPeeringConnectionInfo sends the peering connections with the primary CIDR blocks of both VPCs.
+// VPCs in other accounts or regions are named by id.
+func GetVpcPeeringConnectionInfo(ich chan<- VpcPeeringConnectionInfo, pcxs []types.VpcPeeringConnection, region string, vpcs map[string]types.Vpc, n *Namer) {
+	for _, pcx := range pcxs {
+		obj := VpcPeeringConnectionInfo{
+			VpcPeeringConnectionId:   aws.ToString(pcx.VpcPeeringConnectionId),
+			VpcPeeringConnectionName: n.name(pcx.Tags, pcx.VpcPeeringConnectionId),
+			Status:                   getEc2VpcPeeringConnectionState(pcx),
+			Region:                   region,
+		}
+		if info := pcx.RequesterVpcInfo; info != nil {
+			obj.RequesterVpcId = aws.ToString(info.VpcId)
+			obj.RequesterVpcName = getEc2PeerVpcName(info, vpcs, n)
+			obj.RequesterOwnerId = aws.ToString(info.OwnerId)
+			obj.RequesterRegion = aws.ToString(info.Region)
+			obj.RequesterCidrBlock = aws.ToString(info.CidrBlock)
+		}
+		if info := pcx.AccepterVpcInfo; info != nil {
+			obj.AccepterVpcId = aws.ToString(info.VpcId)
+			obj.AccepterVpcName = getEc2PeerVpcName(info, vpcs, n)
+			obj.AccepterOwnerId = aws.ToString(info.OwnerId)
+			obj.AccepterRegion = aws.ToString(info.Region)
+			obj.AccepterCidrBlock = aws.ToString(info.CidrBlock)
+		}
+		ich <- obj
+	}
+}
+
+type VpcPeeringConnectionCidrInfo struct {
+	VpcPeeringConnectionId   string
+	VpcPeeringConnectionName string
+	Status                   string
+	Side                     string
+	VpcId                    string
+	VpcName                  string
+	OwnerId                  string
+	AddressType              string
+	CidrBlock                string
+	Region                   string
+}
+
+// GetVpcPeeringConnectionCidrInfo sends the peering connections with every IPv4 and IPv6 CIDR block
+// of the requester and accepter VPCs.
+func GetVpcPeeringConnectionCidrInfo(ich chan<- VpcPeeringConnectionCidrInfo, pcxs []types.VpcPeeringConnection, region string, vpcs map[string]types.Vpc, n *Namer) {
+	for _, pcx := range pcxs {
+		obj := VpcPeeringConnectionCidrInfo{
+			VpcPeeringConnectionId:   aws.ToString(pcx.VpcPeeringConnectionId),
+			VpcPeeringConnectionName: n.name(pcx.Tags, pcx.VpcPeeringConnectionId),
+			Status:                   getEc2VpcPeeringConnectionState(pcx),
+			Region:                   region,
+		}
+		for i, info := range getEc2PeeringSides(pcx) {
+			if info == nil {
+				continue
+			}
+			row := obj
+			row.Side = peeringSide(i).String()
+			row.VpcId = aws.ToString(info.VpcId)
+			row.VpcName = getEc2PeerVpcName(info, vpcs, n)
+			row.OwnerId = aws.ToString(info.OwnerId)
+			for _, cidr := range getEc2PeerCidrBlocks(info) {
+				row.AddressType = addressTypeIpv4.String()
+				if cidr.Addr().Is6() {
+					row.AddressType = addressTypeIpv6.String()
+				}
+				row.CidrBlock = cidr.String()
+				ich <- row
+			}
+		}
+	}
+}
+
+type VpcPeeringConnectionRouteInfo struct {
+	VpcPeeringConnectionId   string
+	VpcPeeringConnectionName string
+	Status                   string
+	Symmetric                bool
+	Side                     string
+	VpcId                    string
+	VpcName                  string
+	RouteTableId             string
+	RouteTableName           string
+	PeerCidrBlock            string
+	RouteCheck               string
+	Destination              string
+	Target                   string
+	Region                   string
+}
+
+// VpcPeeringRouteData holds the VPCs and route tables of a region read by the route check of peering connections.
+type VpcPeeringRouteData struct {
+	Vpcs        map[string]types.Vpc
+	RouteTables map[string]types.RouteTable
+}
+
+func FetchDataForVpcPeeringConnectionRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (*VpcPeeringRouteData, error) {
+	data := &VpcPeeringRouteData{}
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		data.Vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		data.RouteTables, err = client.FetchRouteTables(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetVpcPeeringConnectionRouteInfo checks that every associated route table of each VPC routes every CIDR block
+// of the peer VPC to the peering connection, with the data of the region of the VPC. The route with the longest
+// matching prefix decides. Each peering connection is checked once, after every region is fetched, so that both sides
+// of a peering connection across the regions are read. The route tables of a VPC in another account or in a region
+// not fetched cannot be read, and the side is returned once as unknown. Symmetric is true when the route tables
+// of both sides were read and route every CIDR block of the peer.
+func GetVpcPeeringConnectionRouteInfo(pcxs []types.VpcPeeringConnection, data map[string]*VpcPeeringRouteData, n *Namer) []VpcPeeringConnectionRouteInfo {
+	var info []VpcPeeringConnectionRouteInfo
+	seen := make(map[string]bool)
+	for _, pcx := range pcxs {
+		pcxId := aws.ToString(pcx.VpcPeeringConnectionId)
+		if pcx.RequesterVpcInfo == nil || pcx.AccepterVpcInfo == nil || seen[pcxId] {
+			continue
+		}
+		seen[pcxId] = true
+		obj := VpcPeeringConnectionRouteInfo{
+			VpcPeeringConnectionId:   pcxId,
+			VpcPeeringConnectionName: n.name(pcx.Tags, pcx.VpcPeeringConnectionId),
+			Status:                   getEc2VpcPeeringConnectionState(pcx),
+		}
+		sides := getEc2PeeringSides(pcx)
+		var rows []VpcPeeringConnectionRouteInfo
+		symmetric := true
+		for i, side := range sides {
+			peer := sides[len(sides)-1-i]
+			row := obj
+			row.Side = peeringSide(i).String()
+			row.VpcId = aws.ToString(side.VpcId)
+			row.Region = aws.ToString(side.Region)
+			d, ok := data[row.Region]
+			if ok {
+				_, ok = d.Vpcs[row.VpcId]
+			}
+			if !ok {
+				row.VpcName = row.VpcId
+				row.RouteCheck = routeCheckUnknown.String()
+				rows = append(rows, row)
+				symmetric = false
+				continue
+			}
+			row.VpcName = getEc2PeerVpcName(side, d.Vpcs, n)
+			checked := false
+			for _, rtb := range getEc2SortedRouteTables(d.RouteTables) {
+				if aws.ToString(rtb.VpcId) != row.VpcId || len(rtb.Associations) == 0 {
+					continue
+				}
+				checked = true
+				row := row
+				row.RouteTableId = aws.ToString(rtb.RouteTableId)
+				row.RouteTableName = n.name(rtb.Tags, rtb.RouteTableId)
+				for _, cidr := range getEc2PeerCidrBlocks(peer) {
+					row.PeerCidrBlock = cidr.String()
+					check, rt := checkEc2PeeringRoute(rtb, cidr, pcxId)
+					row.RouteCheck = check.String()
+					row.Destination, row.Target = "", ""
+					if rt != nil {
+						_, row.Destination, _ = getEc2RouteDestination(*rt)
+						_, row.Target, _ = getEc2RouteTarget(*rt)
+					}
+					if check != routeCheckRouted {
+						symmetric = false
+					}
+					rows = append(rows, row)
+				}
+			}
+			if !checked {
+				symmetric = false
+			}
+		}
+		for _, row := range rows {
+			row.Symmetric = symmetric
+			info = append(info, row)
+		}
+	}
+	return info
+}
+
+// getEc2SortedRouteTables returns the route tables in the order of their ids.
+func getEc2SortedRouteTables(rtbs map[string]types.RouteTable) []types.RouteTable {
+	res := make([]types.RouteTable, 0, len(rtbs))
+	for _, rtb := range rtbs {
+		res = append(res, rtb)
+	}
+	slices.SortFunc(res, func(a, b types.RouteTable) int {
+		return cmp.Compare(aws.ToString(a.RouteTableId), aws.ToString(b.RouteTableId))
+	})
+	return res
+}
+
+// checkEc2PeeringRoute finds the route with the longest prefix containing the CIDR block
+// and reports whether it is an active route to the peering connection.
+func checkEc2PeeringRoute(rtb types.RouteTable, cidr netip.Prefix, pcxId string) (routeCheck, *types.Route) {
+	best := findEc2Route(rtb, cidr)
+	switch {
+	case best == nil:
+		return routeCheckMissing, nil
+	case aws.ToString(best.VpcPeeringConnectionId) != pcxId:
+		return routeCheckRoutedElsewhere, best
+	case best.State == types.RouteStateBlackhole:
+		return routeCheckBlackhole, best
+	}
+	return routeCheckRouted, best
+}
+
+// getEc2PeeringSides returns the requester and accepter VPCs indexed by their side.
+func getEc2PeeringSides(pcx types.VpcPeeringConnection) []*types.VpcPeeringConnectionVpcInfo {
+	return []*types.VpcPeeringConnectionVpcInfo{
+		peeringSideRequester: pcx.RequesterVpcInfo,
+		peeringSideAccepter:  pcx.AccepterVpcInfo,
+	}
+}
+
+// getEc2PeerCidrBlocks returns every IPv4 and IPv6 CIDR block of the VPC, falling back on the primary one.
+func getEc2PeerCidrBlocks(info *types.VpcPeeringConnectionVpcInfo) []netip.Prefix {
+	var s []string
+	for _, c := range info.CidrBlockSet {
+		s = append(s, aws.ToString(c.CidrBlock))
+	}
+	if len(s) == 0 && info.CidrBlock != nil {
+		s = append(s, aws.ToString(info.CidrBlock))
+	}
+	for _, c := range info.Ipv6CidrBlockSet {
+		s = append(s, aws.ToString(c.Ipv6CidrBlock))
+	}
+	var res []netip.Prefix
+	for _, c := range s {
+		if prefix, err := netip.ParsePrefix(c); err == nil {
+			res = append(res, prefix)
+		}
+	}
+	return res
+}
+
+func getEc2PeerVpcName(info *types.VpcPeeringConnectionVpcInfo, vpcs map[string]types.Vpc, n *Namer) string {
+	if vpc, ok := vpcs[aws.ToString(info.VpcId)]; ok {
+		return n.name(vpc.Tags, vpc.VpcId)
+	}
+	return aws.ToString(info.VpcId)
+}
@@ -0,0 +1,103 @@
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestGetVpcPeeringConnectionRouteInfo(t *testing.T) {
+	vpcInfo := func(id, region, cidr string) *types.VpcPeeringConnectionVpcInfo {
+		return &types.VpcPeeringConnectionVpcInfo{
+			VpcId:     aws.String(id),
+			Region:    aws.String(region),
+			CidrBlock: aws.String(cidr),
+		}
+	}
+	rtb := func(id, vpcId string, routes ...types.Route) types.RouteTable {
+		return types.RouteTable{
+			RouteTableId: aws.String(id),
+			VpcId:        aws.String(vpcId),
+			Associations: []types.RouteTableAssociation{{RouteTableId: aws.String(id)}},
+			Routes:       routes,
+		}
+	}
+	pcx := types.VpcPeeringConnection{
+		VpcPeeringConnectionId: aws.String("pcx-1"),
+		RequesterVpcInfo:       vpcInfo("vpc-a", "ap-northeast-1", "10.0.0.0/16"),
+		AccepterVpcInfo:        vpcInfo("vpc-b", "us-east-1", "10.1.0.0/16"),
+		Status:                 &types.VpcPeeringConnectionStateReason{Code: types.VpcPeeringConnectionStateReasonCodeActive},
+	}
+	data := map[string]*VpcPeeringRouteData{
+		"ap-northeast-1": {
+			Vpcs: map[string]types.Vpc{"vpc-a": {VpcId: aws.String("vpc-a")}},
+			RouteTables: map[string]types.RouteTable{
+				"rtb-a2": rtb("rtb-a2", "vpc-a"),
+				"rtb-a1": rtb("rtb-a1", "vpc-a", types.Route{DestinationCidrBlock: aws.String("10.1.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")}),
+			},
+		},
+		"us-east-1": {
+			Vpcs: map[string]types.Vpc{"vpc-b": {VpcId: aws.String("vpc-b")}},
+			RouteTables: map[string]types.RouteTable{
+				"rtb-b1": rtb("rtb-b1", "vpc-b", types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")}),
+			},
+		},
+	}
+
+	type row struct {
+		Side, Region, RouteTableId, RouteCheck string
+		Symmetric                              bool
+	}
+	tests := []struct {
+		name string
+		data map[string]*VpcPeeringRouteData
+		want []row
+	}{
+		{
+			name: "both regions queried",
+			data: data,
+			want: []row{
+				{"Requester", "ap-northeast-1", "rtb-a1", "Routed", false},
+				{"Requester", "ap-northeast-1", "rtb-a2", "Missing", false},
+				{"Accepter", "us-east-1", "rtb-b1", "Routed", false},
+			},
+		},
+		{
+			name: "symmetric",
+			data: map[string]*VpcPeeringRouteData{
+				"ap-northeast-1": {
+					Vpcs:        data["ap-northeast-1"].Vpcs,
+					RouteTables: map[string]types.RouteTable{"rtb-a1": data["ap-northeast-1"].RouteTables["rtb-a1"]},
+				},
+				"us-east-1": data["us-east-1"],
+			},
+			want: []row{
+				{"Requester", "ap-northeast-1", "rtb-a1", "Routed", true},
+				{"Accepter", "us-east-1", "rtb-b1", "Routed", true},
+			},
+		},
+		{
+			name: "accepter region not queried",
+			data: map[string]*VpcPeeringRouteData{"ap-northeast-1": data["ap-northeast-1"]},
+			want: []row{
+				{"Requester", "ap-northeast-1", "rtb-a1", "Routed", false},
+				{"Requester", "ap-northeast-1", "rtb-a2", "Missing", false},
+				{"Accepter", "us-east-1", "", "Unknown", false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The peering connection is listed in both regions and reported once.
+			var got []row
+			for _, info := range GetVpcPeeringConnectionRouteInfo([]types.VpcPeeringConnection{pcx, pcx}, tt.data, nil) {
+				got = append(got, row{info.Side, info.Region, info.RouteTableId, info.RouteCheck, info.Symmetric})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetVpcPeeringConnectionRouteInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package ec2
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// PrefixListEntries maps prefix list ids to their CIDR blocks.
+// Rules and routes referencing a prefix list are expanded to a row per CIDR block when it is not nil.
+type PrefixListEntries map[string][]string
+
+// FetchPrefixListEntries fetches the CIDR blocks of every prefix list in the region.
+// AWS-managed prefix lists carry their CIDR blocks, the entries of the others are requested one list at a time.
+func FetchPrefixListEntries(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (PrefixListEntries, error) {
+	if err := l.Wait(ctx); err != nil {
+		return nil, err
+	}
+	upls, err := client.FetchPrefixLists(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Wait(ctx); err != nil {
+		return nil, err
+	}
+	mpls, err := client.FetchManagedPrefixLists(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	ples := make(PrefixListEntries, len(upls)+len(mpls))
+	for id, upl := range upls {
+		ples[id] = upl.Cidrs
+	}
+	var mu sync.Mutex
+	eg, ctx := errgroup.WithContext(ctx)
+	for id, mpl := range mpls {
+		if _, ok := ples[id]; ok {
+			continue
+		}
+		id, mpl := id, mpl
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			entries, err := client.GetManagedPrefixListEntries(ctx, region, mpl.PrefixListId)
+			if err != nil {
+				return err
+			}
+			cidrs := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				cidrs = append(cidrs, aws.ToString(entry.Cidr))
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			ples[id] = cidrs
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return ples, nil
+}
+
+// expand returns the CIDR blocks of the prefix list, or false when the prefix lists are not expanded.
+func (ples PrefixListEntries) expand(id string) ([]string, bool) {
+	if ples == nil {
+		return nil, false
+	}
+	cidrs, ok := ples[id]
+	return cidrs, ok
+}
+
+func getEc2CidrAddressType(cidr string) string {
+	if strings.Contains(cidr, ":") {
+		return addressTypeIpv6.String()
+	}
+	return addressTypeIpv4.String()
+}
@@ -0,0 +1,246 @@
+package ec2
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// PlanSubnetsInput selects the VPC and the blocks planned by PlanSubnets.
+type PlanSubnetsInput struct {
+	VpcId string
+
+	// Prefix is the prefix length of the blocks, between 16 and 28 as allowed for subnets.
+	// Count is the number of blocks, 1 by default.
+	Prefix int
+	Count  int
+
+	// AvailabilityZones are assigned to the blocks in turn, leaving the zone empty when none is given.
+	AvailabilityZones []string
+
+	Namer *Namer
+}
+
+type PlannedSubnetInfo struct {
+	VpcId               string
+	VpcName             string
+	VpcCidrBlock        string
+	Index               int
+	AvailabilityZone    string
+	CidrBlock           string
+	TotalIpAddressCount int32
+	Region              string
+}
+
+const (
+	minSubnetPrefix = 16
+	maxSubnetPrefix = 28
+)
+
+// PlanSubnets finds the region of the VPC and returns the first free IPv4 blocks of the prefix length
+// in its associated CIDR blocks, in the order of the blocks and of the addresses. A block is free when it does not
+// overlap any subnet of the VPC. It fails when the VPC has not enough free space for the count,
+// or when any of the availability zones is not in the region of the VPC.
+func PlanSubnets(ctx context.Context, client IEc2Client, regions []string, in *PlanSubnetsInput) ([]PlannedSubnetInfo, error) {
+	if in.VpcId == "" {
+		return nil, fmt.Errorf("invalid input: vpc is required")
+	}
+	if in.Prefix < minSubnetPrefix || in.Prefix > maxSubnetPrefix {
+		return nil, fmt.Errorf("invalid input: prefix must be between %d and %d: %d", minSubnetPrefix, maxSubnetPrefix, in.Prefix)
+	}
+	count := in.Count
+	if count <= 0 {
+		count = 1
+	}
+	var (
+		mu     sync.Mutex
+		vpc    *types.Vpc
+		sbns   []types.Subnet
+		region string
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, r := range regions {
+		// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/throttling.html
+		l := rate.NewLimiter(rate.Limit(50), 1)
+		eg.Go(func() error {
+			v, s, err := fetchEc2PlannedVpc(ctx, l, client, r, in.VpcId)
+			if err != nil || v == nil {
+				return err
+			}
+			if err := validateEc2AvailabilityZones(ctx, l, client, r, in.AvailabilityZones); err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			vpc, sbns, region = v, s, r
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	if vpc == nil {
+		return nil, fmt.Errorf("cannot find vpc in the regions: %s", in.VpcId)
+	}
+	var used []netip.Prefix
+	for _, sbn := range sbns {
+		if prefix, err := netip.ParsePrefix(aws.ToString(sbn.CidrBlock)); err == nil {
+			used = append(used, prefix)
+		}
+	}
+	obj := PlannedSubnetInfo{
+		VpcId:   in.VpcId,
+		VpcName: in.Namer.name(vpc.Tags, vpc.VpcId),
+		Region:  region,
+	}
+	var info []PlannedSubnetInfo
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState != nil && assoc.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+		cidr, err := netip.ParsePrefix(aws.ToString(assoc.CidrBlock))
+		if err != nil || cidr.Bits() > in.Prefix {
+			continue
+		}
+		obj.VpcCidrBlock = cidr.String()
+		for _, block := range findEc2FreeBlocks(cidr.Masked(), in.Prefix, used, count-len(info)) {
+			row := obj
+			row.Index = len(info) + 1
+			if len(in.AvailabilityZones) > 0 {
+				row.AvailabilityZone = in.AvailabilityZones[len(info)%len(in.AvailabilityZones)]
+			}
+			row.CidrBlock = block.String()
+			row.TotalIpAddressCount = getEc2SubnetIpAddressCount(row.CidrBlock)
+			info = append(info, row)
+		}
+		if len(info) == count {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("not enough free space in vpc for %d blocks of /%d, found %d: %s", count, in.Prefix, len(info), in.VpcId)
+}
+
+// fetchEc2PlannedVpc returns the VPC and its subnets in the region, or nil when the VPC is not in the region.
+func fetchEc2PlannedVpc(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, vpcId string) (*types.Vpc, []types.Subnet, error) {
+	filters := []types.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		},
+	}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	if err := l.Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	vpcs, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{Filters: filters}, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(vpcs.Vpcs) == 0 {
+		return nil, nil, nil
+	}
+	var (
+		token *string
+		sbns  []types.Subnet
+	)
+	for {
+		if err := l.Wait(ctx); err != nil {
+			return nil, nil, err
+		}
+		o, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{Filters: filters, NextToken: token}, opt)
+		if err != nil {
+			return nil, nil, err
+		}
+		sbns = append(sbns, o.Subnets...)
+		token = o.NextToken
+		if token == nil {
+			break
+		}
+	}
+	return &vpcs.Vpcs[0], sbns, nil
+}
+
+// validateEc2AvailabilityZones fails when any of the zones is not an available zone of the region.
+func validateEc2AvailabilityZones(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, zones []string) error {
+	if len(zones) == 0 {
+		return nil
+	}
+	if err := l.Wait(ctx); err != nil {
+		return err
+	}
+	input := &ec2.DescribeAvailabilityZonesInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("zone-name"),
+				Values: zones,
+			},
+		},
+	}
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeAvailabilityZones(ctx, input, opt)
+	if err != nil {
+		return err
+	}
+	var invalid []string
+	for _, zone := range zones {
+		if !slices.ContainsFunc(o.AvailabilityZones, func(az types.AvailabilityZone) bool {
+			return aws.ToString(az.ZoneName) == zone
+		}) {
+			invalid = append(invalid, zone)
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid input: availability zones not found in %s: %s", region, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// findEc2FreeBlocks returns up to n blocks of the prefix length in the CIDR block not overlapping any used one.
+// A used block larger than the candidate is skipped as a whole.
+func findEc2FreeBlocks(cidr netip.Prefix, bits int, used []netip.Prefix, n int) []netip.Prefix {
+	var res []netip.Prefix
+	block := netip.PrefixFrom(cidr.Addr(), bits)
+	for len(res) < n && cidr.Contains(block.Addr()) {
+		end := lastEc2Addr(block)
+		free := true
+		for _, u := range used {
+			if !u.Overlaps(block) {
+				continue
+			}
+			free = false
+			if last := lastEc2Addr(u); last.Compare(end) > 0 {
+				end = last
+			}
+		}
+		if free {
+			res = append(res, block)
+		}
+		if !end.Next().IsValid() {
+			break
+		}
+		block = netip.PrefixFrom(end.Next(), bits)
+	}
+	return res
+}
+
+// lastEc2Addr returns the last address of the IPv4 block.
+func lastEc2Addr(prefix netip.Prefix) netip.Addr {
+	a := prefix.Masked().Addr().As4()
+	v := binary.BigEndian.Uint32(a[:]) | (uint32(1)<<(32-prefix.Bits()) - 1)
+	binary.BigEndian.PutUint32(a[:], v)
+	return netip.AddrFrom4(a)
+}
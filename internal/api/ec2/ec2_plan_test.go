@@ -0,0 +1,103 @@
+package ec2
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func Test_findEc2FreeBlocks(t *testing.T) {
+	used := []string{"10.0.0.0/24", "10.0.2.0/23", "10.0.5.16/28"}
+	tests := []struct {
+		name string
+		cidr string
+		bits int
+		used []string
+		n    int
+		want []string
+	}{
+		{
+			name: "larger used block skipped",
+			cidr: "10.0.0.0/16",
+			bits: 24,
+			used: used,
+			n:    4,
+			want: []string{"10.0.1.0/24", "10.0.4.0/24", "10.0.6.0/24", "10.0.7.0/24"},
+		},
+		{
+			name: "limited by the block",
+			cidr: "10.0.0.0/22",
+			bits: 24,
+			used: used,
+			n:    4,
+			want: []string{"10.0.1.0/24"},
+		},
+		{
+			name: "limited by n",
+			cidr: "10.0.0.0/16",
+			bits: 20,
+			used: used,
+			n:    2,
+			want: []string{"10.0.16.0/20", "10.0.32.0/20"},
+		},
+		{
+			name: "block of the same size",
+			cidr: "10.0.0.0/24",
+			bits: 24,
+			used: nil,
+			n:    2,
+			want: []string{"10.0.0.0/24"},
+		},
+		{
+			name: "fully used",
+			cidr: "10.0.2.0/23",
+			bits: 28,
+			used: used,
+			n:    1,
+			want: nil,
+		},
+		{
+			name: "end of the address space",
+			cidr: "255.255.255.0/24",
+			bits: 26,
+			used: nil,
+			n:    10,
+			want: []string{"255.255.255.0/26", "255.255.255.64/26", "255.255.255.128/26", "255.255.255.192/26"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []netip.Prefix
+			for _, u := range tt.used {
+				used = append(used, netip.MustParsePrefix(u))
+			}
+			var got []string
+			for _, block := range findEc2FreeBlocks(netip.MustParsePrefix(tt.cidr), tt.bits, used, tt.n) {
+				got = append(got, block.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("findEc2FreeBlocks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_lastEc2Addr(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "10.0.2.0/23", want: "10.0.3.255"},
+		{prefix: "10.0.5.16/28", want: "10.0.5.31"},
+		{prefix: "10.0.5.20/28", want: "10.0.5.31"},
+		{prefix: "10.0.0.1/32", want: "10.0.0.1"},
+		{prefix: "0.0.0.0/0", want: "255.255.255.255"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if got := lastEc2Addr(netip.MustParsePrefix(tt.prefix)); got.String() != tt.want {
+				t.Errorf("lastEc2Addr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,824 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// ReachInput selects the flow checked by Reach.
+type ReachInput struct {
+	// Source and Destination are an instance id, a network interface id, an IP address or a CIDR block.
+	Source      string
+	Destination string
+
+	// Protocol is tcp, udp, icmp, icmpv6, all or a protocol number, tcp by default.
+	// Port is required for tcp and udp and ignored otherwise.
+	Protocol string
+	Port     int32
+
+	Namer *Namer
+}
+
+type ReachabilityInfo struct {
+	Source       string
+	Destination  string
+	IpProtocol   string
+	Port         int32
+	Reachable    bool
+	Step         int
+	Check        string
+	ResourceId   string
+	ResourceName string
+	Action       string
+	Rule         string
+	Region       string
+}
+
+// Reach checks whether the source can open the flow to the destination from the fetched VPC data of every region
+// where either of them is found in a subnet. A row is returned per check in the order the traffic meets them:
+// the security groups and network ACL of the source, the route tables with the peering connection or transit gateway
+// on the way, the network ACL and security groups of the destination, then the network ACLs again for the return
+// traffic, which they do not track. Internet addresses outside the VPCs are reached through an internet gateway,
+// or a NAT gateway on the way out, and need a public address on the VPC side. Checks stop at the first deny,
+// the rule deciding the flow. An address or CIDR block found in subnets of several regions is ambiguous and fails.
+func Reach(ctx context.Context, client IEc2Client, regions []string, in *ReachInput) ([]ReachabilityInfo, error) {
+	protocol, err := parseEc2Protocol(in.Protocol)
+	if err != nil {
+		return nil, err
+	}
+	if in.Source == "" || in.Destination == "" {
+		return nil, fmt.Errorf("invalid input: source and destination are required")
+	}
+	if isEc2PortRangeProtocol(protocol) && (in.Port <= 0 || in.Port > 65535) {
+		return nil, fmt.Errorf("invalid input: port must be between 1 and 65535 for %s: %d", protocol, in.Port)
+	}
+	rrs, err := resolveEc2ReachRegions(ctx, client, regions, in)
+	if err != nil {
+		return nil, err
+	}
+	info, err := api.Collect(func(ich chan<- ReachabilityInfo) error {
+		eg, ctx := errgroup.WithContext(ctx)
+		for _, rr := range rrs {
+			eg.Go(func() error {
+				r := &reach{
+					nw:       rr.nw,
+					src:      rr.src,
+					dst:      rr.dst,
+					protocol: protocol,
+					port:     in.Port,
+					region:   rr.region,
+					n:        in.Namer,
+				}
+				if err := r.run(ctx, rr.l, client); err != nil {
+					return err
+				}
+				for _, row := range r.rows {
+					ich <- row
+				}
+				return nil
+			})
+		}
+		return eg.Wait()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(info) == 0 {
+		return nil, fmt.Errorf("no subnet found for source or destination in the regions: %s, %s", in.Source, in.Destination)
+	}
+	return info, nil
+}
+
+// reachRegion holds the network of a region where the source or the destination is found in a subnet.
+type reachRegion struct {
+	region string
+	l      *rate.Limiter
+	nw     *reachNetwork
+	src    *reachEndpoint
+	dst    *reachEndpoint
+}
+
+// resolveEc2ReachRegions fetches the network of every region and returns the regions where the source
+// or the destination is found in a subnet. An endpoint found in subnets of several regions, as happens with an address
+// of VPCs sharing CIDR blocks across the regions, is ambiguous and fails, since each region would check another flow.
+func resolveEc2ReachRegions(ctx context.Context, client IEc2Client, regions []string, in *ReachInput) ([]*reachRegion, error) {
+	var (
+		mu  sync.Mutex
+		rrs []*reachRegion
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, region := range regions {
+		// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/throttling.html
+		l := rate.NewLimiter(rate.Limit(50), 1)
+		eg.Go(func() error {
+			nw, err := fetchReachNetwork(ctx, l, client, region)
+			if err != nil {
+				return err
+			}
+			src, err := nw.resolve(in.Source, in.Namer)
+			if err != nil {
+				return err
+			}
+			dst, err := nw.resolve(in.Destination, in.Namer)
+			if err != nil {
+				return err
+			}
+			if src == nil || dst == nil || src.Subnet == nil && dst.Subnet == nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			rrs = append(rrs, &reachRegion{region: region, l: l, nw: nw, src: src, dst: dst})
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	if err := checkEc2ReachRegions(in.Source, rrs, func(rr *reachRegion) *reachEndpoint { return rr.src }); err != nil {
+		return nil, err
+	}
+	if err := checkEc2ReachRegions(in.Destination, rrs, func(rr *reachRegion) *reachEndpoint { return rr.dst }); err != nil {
+		return nil, err
+	}
+	return rrs, nil
+}
+
+// checkEc2ReachRegions fails when the endpoint is found in subnets of several regions.
+func checkEc2ReachRegions(s string, rrs []*reachRegion, ep func(rr *reachRegion) *reachEndpoint) error {
+	var regions []string
+	for _, rr := range rrs {
+		if ep(rr).Subnet != nil {
+			regions = append(regions, rr.region)
+		}
+	}
+	if len(regions) < 2 {
+		return nil
+	}
+	slices.Sort(regions)
+	return fmt.Errorf("ambiguous endpoint: %s: in regions %s: use an instance id or a network interface id, or query one region", s, strings.Join(regions, ", "))
+}
+
+// parseEc2Protocol returns the protocol named as in security group rules.
+func parseEc2Protocol(s string) (string, error) {
+	switch s = strings.ToLower(s); s {
+	case "", "tcp":
+		return "tcp", nil
+	case "all", "-1":
+		return "-1", nil
+	case "udp", "icmp", "icmpv6":
+		return s, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return getEc2ProtocolName(s), nil
+	}
+	return "", fmt.Errorf("invalid protocol: %s: valid values: tcp|udp|icmp|icmpv6|all|<number>", s)
+}
+
+type reachNetwork struct {
+	*Network
+	NetworkInterfaces         map[string]types.NetworkInterface
+	Instances                 map[string]types.Instance
+	VpcPeeringConnections     map[string]types.VpcPeeringConnection
+	TransitGatewayAttachments map[string]types.TransitGatewayAttachment
+	NatGateways               map[string]types.NatGateway
+	PrefixListEntries         PrefixListEntries
+}
+
+func fetchReachNetwork(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (*reachNetwork, error) {
+	nw := &reachNetwork{}
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() (err error) {
+		nw.Network, err = FetchNetwork(ctx, l, client, region)
+		return err
+	})
+	eg.Go(func() (err error) {
+		nw.PrefixListEntries, err = FetchPrefixListEntries(ctx, l, client, region)
+		return err
+	})
+	fetch := func(f func(ctx context.Context) error) {
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			return f(ctx)
+		})
+	}
+	fetch(func(ctx context.Context) (err error) {
+		nw.NetworkInterfaces, err = client.FetchNetworkInterfaces(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.Instances, err = client.FetchInstances(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.VpcPeeringConnections, err = client.FetchVpcPeeringConnections(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.TransitGatewayAttachments, err = client.FetchTransitGatewayAttachments(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		nw.NatGateways, err = client.FetchNatGateways(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return nw, nil
+}
+
+// reachEndpoint is an end of the flow. Subnet is nil for addresses outside the VPCs of the region,
+// and Groups and the public addresses are empty for addresses not assigned to a network interface.
+type reachEndpoint struct {
+	Id          string
+	Name        string
+	Prefix      netip.Prefix
+	Subnet      *types.Subnet
+	Groups      []string
+	PublicIp    string
+	Ipv6Address string
+}
+
+// resolve returns the endpoint, or nil when the instance or network interface is not in the region.
+// An IP address is resolved to the network interface it is assigned to, if any. An address or CIDR block matching
+// several network interfaces or subnets, as happens with VPCs sharing CIDR blocks, is ambiguous and fails.
+func (nw *reachNetwork) resolve(s string, n *Namer) (*reachEndpoint, error) {
+	switch {
+	case strings.HasPrefix(s, "i-"):
+		i, ok := nw.Instances[s]
+		if !ok || i.SubnetId == nil {
+			return nil, nil
+		}
+		addr, err := netip.ParseAddr(aws.ToString(i.PrivateIpAddress))
+		if err != nil {
+			return nil, nil
+		}
+		sbn, err := findEc2SubnetById(aws.ToString(i.SubnetId), nw.Subnets)
+		if err != nil {
+			return nil, err
+		}
+		var groups []string
+		for _, g := range i.SecurityGroups {
+			groups = append(groups, aws.ToString(g.GroupId))
+		}
+		return &reachEndpoint{
+			Id:          s,
+			Name:        n.name(i.Tags, i.PrivateDnsName, i.InstanceId),
+			Prefix:      netip.PrefixFrom(addr, addr.BitLen()),
+			Subnet:      sbn,
+			Groups:      groups,
+			PublicIp:    aws.ToString(i.PublicIpAddress),
+			Ipv6Address: aws.ToString(i.Ipv6Address),
+		}, nil
+	case strings.HasPrefix(s, "eni-"):
+		eni, ok := nw.NetworkInterfaces[s]
+		if !ok {
+			return nil, nil
+		}
+		addr, err := netip.ParseAddr(aws.ToString(eni.PrivateIpAddress))
+		if err != nil {
+			return nil, nil
+		}
+		return nw.interfaceEndpoint(eni, addr, n)
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint: %s: must be an instance id, a network interface id, an ip address or a cidr block", s)
+		}
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	prefix = prefix.Masked()
+	if prefix.IsSingleIP() {
+		var ids []string
+		for id, eni := range nw.NetworkInterfaces {
+			if hasEc2InterfaceAddress(eni, prefix.Addr()) {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+		switch {
+		case len(ids) > 1:
+			return nil, fmt.Errorf("ambiguous endpoint: %s: assigned to %s: use an instance id or a network interface id", s, strings.Join(ids, ", "))
+		case len(ids) == 1:
+			return nw.interfaceEndpoint(nw.NetworkInterfaces[ids[0]], prefix.Addr(), n)
+		}
+	}
+	var ids []string
+	for id, sbn := range nw.Subnets {
+		if containsEc2SubnetPrefix(sbn, prefix) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	switch {
+	case len(ids) > 1:
+		return nil, fmt.Errorf("ambiguous endpoint: %s: in subnets %s: use an instance id or a network interface id", s, strings.Join(ids, ", "))
+	case len(ids) == 1:
+		sbn := nw.Subnets[ids[0]]
+		return &reachEndpoint{
+			Id:     s,
+			Name:   s,
+			Prefix: prefix,
+			Subnet: &sbn,
+		}, nil
+	}
+	return &reachEndpoint{
+		Id:     s,
+		Name:   s,
+		Prefix: prefix,
+	}, nil
+}
+
+func (nw *reachNetwork) interfaceEndpoint(eni types.NetworkInterface, addr netip.Addr, n *Namer) (*reachEndpoint, error) {
+	sbn, err := findEc2SubnetById(aws.ToString(eni.SubnetId), nw.Subnets)
+	if err != nil {
+		return nil, err
+	}
+	var groups []string
+	for _, g := range eni.Groups {
+		groups = append(groups, aws.ToString(g.GroupId))
+	}
+	obj := &reachEndpoint{
+		Id:     aws.ToString(eni.NetworkInterfaceId),
+		Name:   n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId),
+		Prefix: netip.PrefixFrom(addr, addr.BitLen()),
+		Subnet: sbn,
+		Groups: groups,
+	}
+	if eni.Association != nil {
+		obj.PublicIp = aws.ToString(eni.Association.PublicIp)
+	}
+	if len(eni.Ipv6Addresses) > 0 {
+		obj.Ipv6Address = aws.ToString(eni.Ipv6Addresses[0].Ipv6Address)
+	}
+	return obj, nil
+}
+
+func hasEc2InterfaceAddress(eni types.NetworkInterface, addr netip.Addr) bool {
+	var s []string
+	for _, a := range eni.PrivateIpAddresses {
+		s = append(s, aws.ToString(a.PrivateIpAddress))
+	}
+	for _, a := range eni.Ipv6Addresses {
+		s = append(s, aws.ToString(a.Ipv6Address))
+	}
+	return slices.ContainsFunc(s, func(v string) bool {
+		a, err := netip.ParseAddr(v)
+		return err == nil && a == addr
+	})
+}
+
+func containsEc2SubnetPrefix(sbn types.Subnet, prefix netip.Prefix) bool {
+	s := []string{aws.ToString(sbn.CidrBlock)}
+	for _, assoc := range sbn.Ipv6CidrBlockAssociationSet {
+		s = append(s, aws.ToString(assoc.Ipv6CidrBlock))
+	}
+	return slices.ContainsFunc(s, func(v string) bool {
+		p, err := netip.ParsePrefix(v)
+		return err == nil && containsEc2Prefix(p, prefix)
+	})
+}
+
+type reach struct {
+	nw       *reachNetwork
+	src      *reachEndpoint
+	dst      *reachEndpoint
+	protocol string
+	port     int32
+	region   string
+	n        *Namer
+	rows     []ReachabilityInfo
+}
+
+// add appends a check and reports whether the flow goes on.
+func (r *reach) add(check reachCheck, id, name string, action reachAction, rule string) bool {
+	r.rows = append(r.rows, ReachabilityInfo{
+		Source:       r.src.Id,
+		Destination:  r.dst.Id,
+		IpProtocol:   r.protocol,
+		Port:         r.port,
+		Step:         len(r.rows) + 1,
+		Check:        check.String(),
+		ResourceId:   id,
+		ResourceName: name,
+		Action:       action.String(),
+		Rule:         rule,
+		Region:       r.region,
+	})
+	return action != reachActionDeny
+}
+
+func (r *reach) run(ctx context.Context, l *rate.Limiter, client IEc2Client) error {
+	ephemeral := portRange{}
+	if isEc2PortRangeProtocol(r.protocol) {
+		ephemeral = ephemeralPorts
+	}
+	port := portRange{From: r.port, To: r.port}
+	checks := []func() (bool, error){
+		func() (bool, error) {
+			return r.checkSecurityGroups(reachCheckSourceSecurityGroup, r.src, r.dst, true)
+		},
+		func() (bool, error) {
+			return r.checkNetworkAcl(reachCheckSourceNetworkAcl, r.src, r.dst, true, port)
+		},
+		func() (bool, error) {
+			return r.checkRoutes(ctx, l, client)
+		},
+		func() (bool, error) {
+			return r.checkNetworkAcl(reachCheckDestinationNetworkAcl, r.dst, r.src, false, port)
+		},
+		func() (bool, error) {
+			return r.checkSecurityGroups(reachCheckDestinationSecurityGroup, r.dst, r.src, false)
+		},
+		func() (bool, error) {
+			return r.checkNetworkAcl(reachCheckDestinationNetworkAclReturn, r.dst, r.src, true, ephemeral)
+		},
+		func() (bool, error) {
+			return r.checkNetworkAcl(reachCheckSourceNetworkAclReturn, r.src, r.dst, false, ephemeral)
+		},
+	}
+	reachable := true
+	for _, check := range checks {
+		ok, err := check()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			reachable = false
+			break
+		}
+	}
+	for i := range r.rows {
+		r.rows[i].Reachable = reachable
+	}
+	return nil
+}
+
+// checkSecurityGroups looks for a rule of the security groups of the endpoint allowing the flow with the peer.
+// Endpoints without security groups are not checked.
+func (r *reach) checkSecurityGroups(check reachCheck, ep, peer *reachEndpoint, egress bool) (bool, error) {
+	if len(ep.Groups) == 0 {
+		return true, nil
+	}
+	for _, id := range ep.Groups {
+		sg, err := findEc2SecurityGroupById(id, r.nw.SecurityGroups)
+		if err != nil {
+			return false, err
+		}
+		if rule, ok := r.matchSecurityGroup(*sg, peer, egress); ok {
+			return r.add(check, id, aws.ToString(sg.GroupName), reachActionAllow, rule), nil
+		}
+	}
+	direction := "inbound"
+	if egress {
+		direction = "outbound"
+	}
+	return r.add(check, strings.Join(ep.Groups, ","), "", reachActionDeny, fmt.Sprintf("no %s rule allows %s", direction, formatEc2Ports(r.protocol, r.port, r.port))), nil
+}
+
+func (r *reach) matchSecurityGroup(sg types.SecurityGroup, peer *reachEndpoint, egress bool) (string, bool) {
+	perms, direction := sg.IpPermissions, "from"
+	if egress {
+		perms, direction = sg.IpPermissionsEgress, "to"
+	}
+	contains := func(cidr string) bool {
+		p, err := netip.ParsePrefix(cidr)
+		return err == nil && containsEc2Prefix(p, peer.Prefix)
+	}
+	for _, perm := range perms {
+		protocol := getEc2ProtocolName(aws.ToString(perm.IpProtocol))
+		if protocol != "-1" && protocol != r.protocol {
+			continue
+		}
+		if protocol != "-1" && isEc2PortRangeProtocol(protocol) && (r.port < aws.ToInt32(perm.FromPort) || aws.ToInt32(perm.ToPort) < r.port) {
+			continue
+		}
+		ports := formatEc2Ports(protocol, aws.ToInt32(perm.FromPort), aws.ToInt32(perm.ToPort))
+		for _, ipv4 := range perm.IpRanges {
+			if contains(aws.ToString(ipv4.CidrIp)) {
+				return fmt.Sprintf("%s %s %s", ports, direction, aws.ToString(ipv4.CidrIp)), true
+			}
+		}
+		for _, ipv6 := range perm.Ipv6Ranges {
+			if contains(aws.ToString(ipv6.CidrIpv6)) {
+				return fmt.Sprintf("%s %s %s", ports, direction, aws.ToString(ipv6.CidrIpv6)), true
+			}
+		}
+		for _, group := range perm.UserIdGroupPairs {
+			if slices.Contains(peer.Groups, aws.ToString(group.GroupId)) {
+				return fmt.Sprintf("%s %s %s", ports, direction, aws.ToString(group.GroupId)), true
+			}
+		}
+		for _, pl := range perm.PrefixListIds {
+			for _, cidr := range r.nw.PrefixListEntries[aws.ToString(pl.PrefixListId)] {
+				if contains(cidr) {
+					return fmt.Sprintf("%s %s %s (%s)", ports, direction, aws.ToString(pl.PrefixListId), cidr), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// checkNetworkAcl applies the network ACL of the subnet of the endpoint to the flow with the peer.
+// Traffic within a subnet does not pass its network ACL, and endpoints outside the VPCs are not checked.
+func (r *reach) checkNetworkAcl(check reachCheck, ep, peer *reachEndpoint, egress bool, ports portRange) (bool, error) {
+	if ep.Subnet == nil {
+		return true, nil
+	}
+	sbnId := aws.ToString(ep.Subnet.SubnetId)
+	if peer.Subnet != nil && aws.ToString(peer.Subnet.SubnetId) == sbnId {
+		return r.add(check, sbnId, r.n.name(ep.Subnet.Tags, ep.Subnet.SubnetId), reachActionSkip, "same subnet"), nil
+	}
+	acl, err := findEc2NetworkAclBySubnet(*ep.Subnet, r.nw.NetworkAcls)
+	if err != nil {
+		return false, err
+	}
+	allowed, rule := allowsEc2NetworkAcl(*acl, egress, r.protocol, ports, peer.Prefix)
+	direction := "inbound"
+	if egress {
+		direction = "outbound"
+	}
+	action, verb := reachActionDeny, "denies"
+	if allowed {
+		action, verb = reachActionAllow, "allows"
+	}
+	ruleNumber := strconv.Itoa(int(rule))
+	if rule == defaultNetworkAclRuleNumber {
+		ruleNumber = "*"
+	}
+	desc := fmt.Sprintf("%s rule %s %s %s", direction, ruleNumber, verb, peer.Prefix)
+	return r.add(check, aws.ToString(acl.NetworkAclId), r.n.name(acl.Tags, acl.NetworkAclId), action, desc), nil
+}
+
+// checkRoutes follows the route of the source subnet to the destination, through a peering connection
+// or a transit gateway, and checks the route back from the destination subnet.
+// Traffic with an internet address outside the VPCs must pass an internet gateway, or a NAT gateway on the way out,
+// and the endpoint in the VPC must have a public address for it. A source outside the VPCs with a private address
+// is only checked for the route back from the destination subnet.
+func (r *reach) checkRoutes(ctx context.Context, l *rate.Limiter, client IEc2Client) (bool, error) {
+	if r.src.Subnet == nil {
+		if isEc2InternetPrefix(r.src.Prefix) {
+			return r.checkInternetInbound()
+		}
+		return r.checkReturnRoute(func(*types.Route) bool {
+			return true
+		})
+	}
+	rtb, err := findEc2RouteTableBySubnet(*r.src.Subnet, r.nw.RouteTables)
+	if err != nil {
+		return false, err
+	}
+	rtbId, rtbName := aws.ToString(rtb.RouteTableId), r.n.name(rtb.Tags, rtb.RouteTableId)
+	rt := findEc2Route(*rtb, r.dst.Prefix)
+	if rt == nil {
+		return r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionDeny, fmt.Sprintf("no route to %s", r.dst.Prefix)), nil
+	}
+	_, target, err := getEc2RouteTarget(*rt)
+	if err != nil {
+		return false, err
+	}
+	_, destination, err := getEc2RouteDestination(*rt)
+	if err != nil {
+		return false, err
+	}
+	desc := fmt.Sprintf("%s via %s", destination, target)
+	if rt.State == types.RouteStateBlackhole {
+		return r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionDeny, desc+" is blackhole"), nil
+	}
+	srcVpc := aws.ToString(r.src.Subnet.VpcId)
+	switch {
+	case r.dst.Subnet == nil && isEc2InternetPrefix(r.dst.Prefix) && rt.VpcPeeringConnectionId == nil && rt.TransitGatewayId == nil:
+		return r.checkInternetOutbound(*rt, rtbId, rtbName, desc)
+	case rt.VpcPeeringConnectionId != nil:
+		r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionAllow, desc)
+		return r.checkPeering(aws.ToString(rt.VpcPeeringConnectionId))
+	case rt.TransitGatewayId != nil:
+		r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionAllow, desc)
+		return r.checkTransitGateway(ctx, l, client, srcVpc, aws.ToString(rt.TransitGatewayId))
+	case r.dst.Subnet != nil && aws.ToString(r.dst.Subnet.VpcId) != srcVpc:
+		return r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionDeny, fmt.Sprintf("%s does not lead to %s", desc, aws.ToString(r.dst.Subnet.VpcId))), nil
+	}
+	return r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionAllow, desc), nil
+}
+
+// checkInternetInbound checks that the destination has a public address of the family of the source
+// and that its subnet routes the default route to an internet gateway, for the traffic back to the source.
+func (r *reach) checkInternetInbound() (bool, error) {
+	ipv6 := r.src.Prefix.Addr().Is6()
+	if !r.checkPublicAddress(r.dst, ipv6) {
+		return false, nil
+	}
+	rtb, err := findEc2RouteTableBySubnet(*r.dst.Subnet, r.nw.RouteTables)
+	if err != nil {
+		return false, err
+	}
+	rtbId, rtbName := aws.ToString(rtb.RouteTableId), r.n.name(rtb.Tags, rtb.RouteTableId)
+	world := getEc2World(ipv6)
+	rt, ok := internetRoute(*rtb, ipv6)
+	if !ok {
+		return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionDeny, fmt.Sprintf("no route %s to internet gateway", world)), nil
+	}
+	return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionAllow, fmt.Sprintf("%s via %s", world, aws.ToString(rt.GatewayId))), nil
+}
+
+// checkInternetOutbound checks the route of the source subnet to an internet address. An internet gateway
+// requires a public address of the source, an egress-only internet gateway an IPv6 address, and a NAT gateway
+// to be public, available and in a subnet routing the default route to an internet gateway.
+func (r *reach) checkInternetOutbound(rt types.Route, rtbId, rtbName, desc string) (bool, error) {
+	ipv6 := r.dst.Prefix.Addr().Is6()
+	switch {
+	case strings.HasPrefix(aws.ToString(rt.GatewayId), "igw-"), rt.EgressOnlyInternetGatewayId != nil:
+		r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionAllow, desc)
+		return r.checkPublicAddress(r.src, ipv6), nil
+	case rt.NatGatewayId != nil:
+		r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionAllow, desc)
+		return r.checkNatGateway(aws.ToString(rt.NatGatewayId))
+	}
+	return r.add(reachCheckSourceRouteTable, rtbId, rtbName, reachActionDeny, desc+" does not lead to the internet"), nil
+}
+
+func (r *reach) checkNatGateway(natId string) (bool, error) {
+	nat, ok := r.nw.NatGateways[natId]
+	if !ok {
+		return r.add(reachCheckNatGateway, natId, "", reachActionDeny, "nat gateway not found"), nil
+	}
+	name := r.n.name(nat.Tags, nat.NatGatewayId)
+	switch {
+	case nat.State != types.NatGatewayStateAvailable:
+		return r.add(reachCheckNatGateway, natId, name, reachActionDeny, fmt.Sprintf("state is %s", nat.State)), nil
+	case nat.ConnectivityType == types.ConnectivityTypePrivate:
+		return r.add(reachCheckNatGateway, natId, name, reachActionDeny, "private nat gateway"), nil
+	}
+	sbn, err := findEc2SubnetById(aws.ToString(nat.SubnetId), r.nw.Subnets)
+	if err != nil {
+		return false, err
+	}
+	rtb, err := findEc2RouteTableBySubnet(*sbn, r.nw.RouteTables)
+	if err != nil {
+		return false, err
+	}
+	rt, ok := internetRoute(*rtb, false)
+	if !ok {
+		return r.add(reachCheckNatGateway, natId, name, reachActionDeny, fmt.Sprintf("%s has no route 0.0.0.0/0 to internet gateway", aws.ToString(rtb.RouteTableId))), nil
+	}
+	return r.add(reachCheckNatGateway, natId, name, reachActionAllow, fmt.Sprintf("%s routes 0.0.0.0/0 via %s", aws.ToString(rtb.RouteTableId), aws.ToString(rt.GatewayId))), nil
+}
+
+// checkPublicAddress checks that the endpoint has a public IPv4 address or an IPv6 address.
+func (r *reach) checkPublicAddress(ep *reachEndpoint, ipv6 bool) bool {
+	addr, family := ep.PublicIp, "ipv4"
+	if ipv6 {
+		addr, family = ep.Ipv6Address, "ipv6"
+	}
+	if addr == "" {
+		return r.add(reachCheckPublicAddress, ep.Id, ep.Name, reachActionDeny, fmt.Sprintf("no public %s address", family))
+	}
+	return r.add(reachCheckPublicAddress, ep.Id, ep.Name, reachActionAllow, fmt.Sprintf("public ip %s", addr))
+}
+
+// isEc2InternetPrefix reports whether the addresses are reached through the internet rather than a private network.
+func isEc2InternetPrefix(prefix netip.Prefix) bool {
+	addr := prefix.Addr()
+	return !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast()
+}
+
+// getEc2World returns the default route of the family.
+func getEc2World(ipv6 bool) netip.Prefix {
+	if ipv6 {
+		return netip.MustParsePrefix("::/0")
+	}
+	return netip.MustParsePrefix("0.0.0.0/0")
+}
+
+func (r *reach) checkPeering(pcxId string) (bool, error) {
+	pcx, ok := r.nw.VpcPeeringConnections[pcxId]
+	if !ok {
+		return r.add(reachCheckPeeringConnection, pcxId, "", reachActionDeny, "peering connection not found"), nil
+	}
+	name := r.n.name(pcx.Tags, pcx.VpcPeeringConnectionId)
+	if pcx.Status == nil || pcx.Status.Code != types.VpcPeeringConnectionStateReasonCodeActive {
+		return r.add(reachCheckPeeringConnection, pcxId, name, reachActionDeny, fmt.Sprintf("status is %s", getEc2VpcPeeringConnectionState(pcx))), nil
+	}
+	sides := getEc2PeeringSides(pcx)
+	var vpcs []string
+	for _, side := range sides {
+		if side != nil {
+			vpcs = append(vpcs, aws.ToString(side.VpcId))
+		}
+	}
+	desc := fmt.Sprintf("connects %s", strings.Join(vpcs, " and "))
+	if r.dst.Subnet == nil {
+		r.add(reachCheckPeeringConnection, pcxId, name, reachActionAllow, desc)
+		return r.add(reachCheckDestinationRouteTable, "", "", reachActionSkip, "destination outside the vpcs of the region"), nil
+	}
+	dstVpc := aws.ToString(r.dst.Subnet.VpcId)
+	if !slices.Contains(vpcs, dstVpc) {
+		return r.add(reachCheckPeeringConnection, pcxId, name, reachActionDeny, fmt.Sprintf("%s, not %s", desc, dstVpc)), nil
+	}
+	r.add(reachCheckPeeringConnection, pcxId, name, reachActionAllow, desc)
+	return r.checkReturnRoute(func(rt *types.Route) bool {
+		return aws.ToString(rt.VpcPeeringConnectionId) == pcxId
+	})
+}
+
+func (r *reach) checkTransitGateway(ctx context.Context, l *rate.Limiter, client IEc2Client, srcVpc, tgwId string) (bool, error) {
+	var att *types.TransitGatewayAttachment
+	for _, a := range r.nw.TransitGatewayAttachments {
+		if aws.ToString(a.TransitGatewayId) == tgwId && a.ResourceType == types.TransitGatewayAttachmentResourceTypeVpc &&
+			aws.ToString(a.ResourceId) == srcVpc && a.State == types.TransitGatewayAttachmentStateAvailable {
+			att = &a
+			break
+		}
+	}
+	if att == nil {
+		return r.add(reachCheckTransitGateway, tgwId, "", reachActionDeny, fmt.Sprintf("%s has no available attachment", srcVpc)), nil
+	}
+	if att.Association == nil || att.Association.TransitGatewayRouteTableId == nil {
+		return r.add(reachCheckTransitGateway, aws.ToString(att.TransitGatewayAttachmentId), r.n.name(att.Tags, att.TransitGatewayAttachmentId), reachActionDeny, "attachment not associated with a route table"), nil
+	}
+	tgwRtbId := aws.ToString(att.Association.TransitGatewayRouteTableId)
+	if err := l.Wait(ctx); err != nil {
+		return false, err
+	}
+	routes, err := client.SearchTransitGatewayRoutes(ctx, r.region, att.Association.TransitGatewayRouteTableId)
+	if err != nil {
+		return false, err
+	}
+	var best *types.TransitGatewayRoute
+	bits := -1
+	for i, rt := range routes {
+		prefix, err := netip.ParsePrefix(aws.ToString(rt.DestinationCidrBlock))
+		if err != nil {
+			continue
+		}
+		if containsEc2Prefix(prefix, r.dst.Prefix) && prefix.Bits() > bits {
+			best, bits = &routes[i], prefix.Bits()
+		}
+	}
+	if best == nil {
+		return r.add(reachCheckTransitGateway, tgwRtbId, "", reachActionDeny, fmt.Sprintf("no route to %s", r.dst.Prefix)), nil
+	}
+	if best.State == types.TransitGatewayRouteStateBlackhole || len(best.TransitGatewayAttachments) == 0 {
+		return r.add(reachCheckTransitGateway, tgwRtbId, "", reachActionDeny, fmt.Sprintf("%s is blackhole", aws.ToString(best.DestinationCidrBlock))), nil
+	}
+	next := best.TransitGatewayAttachments[0]
+	desc := fmt.Sprintf("%s via %s (%s %s)", aws.ToString(best.DestinationCidrBlock), aws.ToString(next.TransitGatewayAttachmentId), next.ResourceType, aws.ToString(next.ResourceId))
+	if r.dst.Subnet == nil {
+		r.add(reachCheckTransitGateway, tgwRtbId, "", reachActionAllow, desc)
+		return r.add(reachCheckDestinationRouteTable, "", "", reachActionSkip, "destination outside the vpcs of the region"), nil
+	}
+	dstVpc := aws.ToString(r.dst.Subnet.VpcId)
+	if next.ResourceType != types.TransitGatewayAttachmentResourceTypeVpc || aws.ToString(next.ResourceId) != dstVpc {
+		return r.add(reachCheckTransitGateway, tgwRtbId, "", reachActionDeny, fmt.Sprintf("%s, not %s", desc, dstVpc)), nil
+	}
+	r.add(reachCheckTransitGateway, tgwRtbId, "", reachActionAllow, desc)
+	return r.checkReturnRoute(func(rt *types.Route) bool {
+		return aws.ToString(rt.TransitGatewayId) == tgwId
+	})
+}
+
+// checkReturnRoute checks that the route table of the destination subnet routes the source back the same way.
+func (r *reach) checkReturnRoute(match func(rt *types.Route) bool) (bool, error) {
+	rtb, err := findEc2RouteTableBySubnet(*r.dst.Subnet, r.nw.RouteTables)
+	if err != nil {
+		return false, err
+	}
+	rtbId, rtbName := aws.ToString(rtb.RouteTableId), r.n.name(rtb.Tags, rtb.RouteTableId)
+	rt := findEc2Route(*rtb, r.src.Prefix)
+	if rt == nil {
+		return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionDeny, fmt.Sprintf("no route back to %s", r.src.Prefix)), nil
+	}
+	_, target, err := getEc2RouteTarget(*rt)
+	if err != nil {
+		return false, err
+	}
+	_, destination, err := getEc2RouteDestination(*rt)
+	if err != nil {
+		return false, err
+	}
+	desc := fmt.Sprintf("%s via %s", destination, target)
+	switch {
+	case rt.State == types.RouteStateBlackhole:
+		return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionDeny, desc+" is blackhole"), nil
+	case !match(rt):
+		return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionDeny, desc+" is not the way back"), nil
+	}
+	return r.add(reachCheckDestinationRouteTable, rtbId, rtbName, reachActionAllow, desc), nil
+}
@@ -0,0 +1,47 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func Test_checkEc2ReachRegions(t *testing.T) {
+	inSubnet := &reachEndpoint{Subnet: &types.Subnet{}}
+	outside := &reachEndpoint{}
+	tests := []struct {
+		name    string
+		rrs     []*reachRegion
+		wantErr bool
+	}{
+		{
+			name: "one region",
+			rrs: []*reachRegion{
+				{region: "ap-northeast-1", src: inSubnet},
+				{region: "us-east-1", src: outside},
+			},
+			wantErr: false,
+		},
+		{
+			name: "several regions",
+			rrs: []*reachRegion{
+				{region: "us-east-1", src: inSubnet},
+				{region: "ap-northeast-1", src: inSubnet},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no region",
+			rrs:     nil,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEc2ReachRegions("10.0.0.5", tt.rrs, func(rr *reachRegion) *reachEndpoint { return rr.src })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkEc2ReachRegions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
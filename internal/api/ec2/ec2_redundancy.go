@@ -0,0 +1,165 @@
+package ec2
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+type redundantRule struct {
+	Rule       SecurityGroupPermissionsInfo
+	Cover      SecurityGroupPermissionsInfo
+	Finding    string
+	Suggestion string
+}
+
+// findEc2RedundantRules compares the rules of a group, or of the groups attached to an instance, in the given order.
+// A rule is reported once: as a duplicate of an earlier identical rule, as subsumed by a rule allowing
+// a wider CIDR block, port range or protocol, or as mergeable with a rule of the same group
+// whose CIDR block forms a supernet with its own.
+func findEc2RedundantRules(perms []SecurityGroupPermissionsInfo) []redundantRule {
+	var res []redundantRule
+	reported := make(map[int]bool)
+	for i, perm := range perms {
+		for j, cover := range perms {
+			if i == j || perm.FlowDirection != cover.FlowDirection || !coversEc2Rule(cover, perm) {
+				continue
+			}
+			if coversEc2Rule(perm, cover) {
+				// identical rules: keep the first one
+				if j > i {
+					continue
+				}
+				res = append(res, redundantRule{
+					Rule:       perm,
+					Cover:      cover,
+					Finding:    redundancyDuplicate.String(),
+					Suggestion: fmt.Sprintf("remove: same rule in %s", cover.SecurityGroupName),
+				})
+			} else {
+				res = append(res, redundantRule{
+					Rule:       perm,
+					Cover:      cover,
+					Finding:    redundancySubsumed.String(),
+					Suggestion: fmt.Sprintf("remove: covered by %s %s in %s", formatEc2Ports(cover.IpProtocol, cover.FromPort, cover.ToPort), cover.CidrBlock, cover.SecurityGroupName),
+				})
+			}
+			reported[i] = true
+			break
+		}
+	}
+	for i, perm := range perms {
+		if reported[i] {
+			continue
+		}
+		for j := i + 1; j < len(perms); j++ {
+			other := perms[j]
+			if reported[j] || other.SecurityGroupId != perm.SecurityGroupId || other.FlowDirection != perm.FlowDirection {
+				continue
+			}
+			if other.IpProtocol != perm.IpProtocol || other.FromPort != perm.FromPort || other.ToPort != perm.ToPort {
+				continue
+			}
+			supernet, ok := getEc2Supernet(perm, other)
+			if !ok {
+				continue
+			}
+			res = append(res, redundantRule{
+				Rule:       perm,
+				Cover:      other,
+				Finding:    redundancyMergeable.String(),
+				Suggestion: fmt.Sprintf("merge with %s into %s", other.CidrBlock, supernet),
+			})
+			reported[i] = true
+			reported[j] = true
+			break
+		}
+	}
+	return res
+}
+
+// coversEc2Rule reports whether every flow allowed by the rule is also allowed by the cover.
+func coversEc2Rule(cover, rule SecurityGroupPermissionsInfo) bool {
+	if cover.AddressType != rule.AddressType {
+		return false
+	}
+	switch cover.AddressType {
+	case addressTypeIpv4.String(), addressTypeIpv6.String():
+		c, err := netip.ParsePrefix(cover.CidrBlock)
+		if err != nil {
+			return false
+		}
+		r, err := netip.ParsePrefix(rule.CidrBlock)
+		if err != nil {
+			return false
+		}
+		if c.Bits() > r.Bits() || !c.Contains(r.Addr()) {
+			return false
+		}
+	default:
+		if cover.CidrBlock != rule.CidrBlock {
+			return false
+		}
+	}
+	switch {
+	case cover.IpProtocol == "-1":
+		return true
+	case cover.IpProtocol != rule.IpProtocol:
+		return false
+	case isEc2PortRangeProtocol(cover.IpProtocol):
+		return cover.FromPort <= rule.FromPort && rule.ToPort <= cover.ToPort
+	default:
+		// icmp type and code, where -1 matches any
+		return (cover.FromPort == -1 || cover.FromPort == rule.FromPort) && (cover.ToPort == -1 || cover.ToPort == rule.ToPort)
+	}
+}
+
+func isEc2PortRangeProtocol(protocol string) bool {
+	switch protocol {
+	case "tcp", "udp", "6", "17":
+		return true
+	}
+	return false
+}
+
+func formatEc2Ports(protocol string, from, to int32) string {
+	switch {
+	case protocol == "-1":
+		return "all"
+	case !isEc2PortRangeProtocol(protocol):
+		return protocol
+	case from == to:
+		return fmt.Sprintf("%s %d", protocol, from)
+	default:
+		return fmt.Sprintf("%s %d-%d", protocol, from, to)
+	}
+}
+
+// getEc2Supernet returns the block one bit shorter when the CIDR blocks of the rules are its two halves.
+func getEc2Supernet(a, b SecurityGroupPermissionsInfo) (netip.Prefix, bool) {
+	if a.AddressType != b.AddressType || (a.AddressType != addressTypeIpv4.String() && a.AddressType != addressTypeIpv6.String()) {
+		return netip.Prefix{}, false
+	}
+	pa, err := netip.ParsePrefix(a.CidrBlock)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	pb, err := netip.ParsePrefix(b.CidrBlock)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	if pa.Bits() != pb.Bits() || pa.Bits() == 0 || pa.Masked() == pb.Masked() {
+		return netip.Prefix{}, false
+	}
+	sa, err := pa.Addr().Prefix(pa.Bits() - 1)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	sb, err := pb.Addr().Prefix(pb.Bits() - 1)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	if sa != sb {
+		return netip.Prefix{}, false
+	}
+	return sa, true
+}
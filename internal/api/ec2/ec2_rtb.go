@@ -2,6 +2,7 @@ package ec2
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -38,6 +39,28 @@ func CreateDescribeRouteTablesInput(ids, names []string, filters []types.Filter,
 	}
 }
 
+var RouteTableLister = &Lister[types.RouteTable]{
+	Name:    "DescribeRouteTables",
+	Filters: routeTableFilterNames,
+	List:    listRouteTables,
+	Named: namedBy(func(item types.RouteTable) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listRouteTables(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.RouteTable, *string, error) {
+	input := CreateDescribeRouteTablesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeRouteTables(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.RouteTables, o.NextToken, nil
+}
+
 type RouteTableInfo struct {
 	RouteTableId    string
 	RouteTableName  string
@@ -47,13 +70,41 @@ type RouteTableInfo struct {
 	Destination     string
 	TargetType      string
 	Target          string
+	TargetName      string
+	TargetState     string
 	State           types.RouteState
+	PrefixList      string
 	Region          string
 }
 
-func GetRouteTableInfo(ich chan<- RouteTableInfo, rtbs []types.RouteTable, region string, vpcs map[string]types.Vpc) error {
+func FetchDataForRouteTableInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, *Gateways, error) {
+	var vpcs map[string]types.Vpc
+	var gws *Gateways
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		gws, err = FetchGateways(ctx, l, client, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return vpcs, gws, nil
+}
+
+// GetRouteTableInfo sends the routes of the route tables.
+// Routes to a prefix list are expanded to its CIDR blocks when the entries are not nil.
+func GetRouteTableInfo(ich chan<- RouteTableInfo, rtbs []types.RouteTable, region string, vpcs map[string]types.Vpc, gws *Gateways, ples PrefixListEntries, n *Namer) error {
 	for _, rtb := range rtbs {
-		routes, err := handleRoutes(rtb, vpcs, region)
+		routes, err := handleRoutes(rtb, vpcs, gws, ples, region, n)
 		if err != nil {
 			return err
 		}
@@ -64,7 +115,7 @@ func GetRouteTableInfo(ich chan<- RouteTableInfo, rtbs []types.RouteTable, regio
 	return nil
 }
 
-func handleRoutes(rtb types.RouteTable, vpcs map[string]types.Vpc, region string) ([]RouteTableInfo, error) {
+func handleRoutes(rtb types.RouteTable, vpcs map[string]types.Vpc, gws *Gateways, ples PrefixListEntries, region string, n *Namer) ([]RouteTableInfo, error) {
 	var info []RouteTableInfo
 	vpcId := aws.ToString(rtb.VpcId)
 	vpc, err := findEc2VpcById(vpcId, vpcs)
@@ -80,18 +131,31 @@ func handleRoutes(rtb types.RouteTable, vpcs map[string]types.Vpc, region string
 		if err != nil {
 			return nil, err
 		}
-		info = append(info, RouteTableInfo{
+		targetName, targetState, _ := gws.routeTarget(rt, n)
+		obj := RouteTableInfo{
 			RouteTableId:    aws.ToString(rtb.RouteTableId),
-			RouteTableName:  getEc2NameTagValue(rtb.Tags),
+			RouteTableName:  n.name(rtb.Tags, rtb.RouteTableId),
 			VpcId:           vpcId,
-			VpcName:         getEc2NameTagValue(vpc.Tags),
+			VpcName:         n.name(vpc.Tags, vpc.VpcId),
 			DestinationType: destinationType,
 			Destination:     destination,
 			TargetType:      targetType,
 			Target:          target,
+			TargetName:      targetName,
+			TargetState:     targetState,
 			State:           rt.State,
 			Region:          region,
-		})
+		}
+		if cidrs, ok := ples.expand(aws.ToString(rt.DestinationPrefixListId)); ok && rt.DestinationPrefixListId != nil {
+			for _, cidr := range cidrs {
+				obj.DestinationType = getEc2CidrAddressType(cidr)
+				obj.Destination = cidr
+				obj.PrefixList = destination
+				info = append(info, obj)
+			}
+			continue
+		}
+		info = append(info, obj)
 	}
 	return info, nil
 }
@@ -134,7 +198,7 @@ func FetchDataForRouteTableAssociationInfo(ctx context.Context, l *rate.Limiter,
 	return vpcs, sbns, nil
 }
 
-func GetRouteTableAssociationInfo(ich chan<- RouteTableAssociationInfo, rtbs []types.RouteTable, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet) error {
+func GetRouteTableAssociationInfo(ich chan<- RouteTableAssociationInfo, rtbs []types.RouteTable, region string, vpcs map[string]types.Vpc, sbns map[string]types.Subnet, n *Namer) error {
 	for _, rtb := range rtbs {
 		vpcId := aws.ToString(rtb.VpcId)
 		vpc, err := findEc2VpcById(vpcId, vpcs)
@@ -149,13 +213,13 @@ func GetRouteTableAssociationInfo(ich chan<- RouteTableAssociationInfo, rtbs []t
 				if err != nil {
 					return err
 				}
-				subnetName = getEc2NameTagValue(sbn.Tags)
+				subnetName = n.name(sbn.Tags, sbn.SubnetId)
 			}
 			ich <- RouteTableAssociationInfo{
 				RouteTableId:   aws.ToString(rtb.RouteTableId),
-				RouteTableName: getEc2NameTagValue(rtb.Tags),
+				RouteTableName: n.name(rtb.Tags, rtb.RouteTableId),
 				VpcId:          vpcId,
-				VpcName:        getEc2NameTagValue(vpc.Tags),
+				VpcName:        n.name(vpc.Tags, vpc.VpcId),
 				Main:           aws.ToBool(assoc.Main),
 				SubnetId:       subnetId,
 				SubnetName:     subnetName,
@@ -166,3 +230,92 @@ func GetRouteTableAssociationInfo(ich chan<- RouteTableAssociationInfo, rtbs []t
 	}
 	return nil
 }
+
+type RouteTableAuditInfo struct {
+	RouteTableId    string
+	RouteTableName  string
+	VpcId           string
+	VpcName         string
+	Finding         string
+	DestinationType string
+	Destination     string
+	TargetType      string
+	Target          string
+	TargetName      string
+	TargetState     string
+	State           types.RouteState
+	Region          string
+}
+
+// GetRouteTableAuditInfo sends the findings of the route tables: blackhole routes, routes whose target
+// no longer exists or is not available, and route tables associated with neither a subnet nor a gateway.
+func GetRouteTableAuditInfo(ich chan<- RouteTableAuditInfo, rtbs []types.RouteTable, region string, vpcs map[string]types.Vpc, gws *Gateways, n *Namer) error {
+	for _, rtb := range rtbs {
+		vpcId := aws.ToString(rtb.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		obj := RouteTableAuditInfo{
+			RouteTableId:   aws.ToString(rtb.RouteTableId),
+			RouteTableName: n.name(rtb.Tags, rtb.RouteTableId),
+			VpcId:          vpcId,
+			VpcName:        n.name(vpc.Tags, vpc.VpcId),
+			Region:         region,
+		}
+		if len(rtb.Associations) == 0 {
+			row := obj
+			row.Finding = routeFindingNoAssociation.String()
+			ich <- row
+		}
+		for _, rt := range rtb.Routes {
+			targetName, targetState, ok := gws.routeTarget(rt, n)
+			var finding routeFinding
+			switch {
+			case rt.State == types.RouteStateBlackhole:
+				finding = routeFindingBlackhole
+			case !tracksRouteTarget(rt):
+				continue
+			case !ok:
+				finding = routeFindingTargetMissing
+			case !isEc2RouteTargetAvailable(rt, targetState):
+				finding = routeFindingTargetNotAvailable
+			default:
+				continue
+			}
+			destinationType, destination, err := getEc2RouteDestination(rt)
+			if err != nil {
+				return err
+			}
+			targetType, target, err := getEc2RouteTarget(rt)
+			if err != nil {
+				return err
+			}
+			row := obj
+			row.Finding = finding.String()
+			row.DestinationType = destinationType
+			row.Destination = destination
+			row.TargetType = targetType
+			row.Target = target
+			row.TargetName = targetName
+			row.TargetState = targetState
+			row.State = rt.State
+			ich <- row
+		}
+	}
+	return nil
+}
+
+// isEc2RouteTargetAvailable reports whether the state of the route target can forward traffic.
+// A network interface must be attached, so "in-use" is the only state accepted for it.
+func isEc2RouteTargetAvailable(rt types.Route, state string) bool {
+	switch {
+	case rt.VpcPeeringConnectionId != nil:
+		return state == string(types.VpcPeeringConnectionStateReasonCodeActive)
+	case rt.InstanceId != nil:
+		return state == string(types.InstanceStateNameRunning)
+	case rt.NetworkInterfaceId != nil:
+		return state == string(types.NetworkInterfaceStatusInUse)
+	}
+	return strings.EqualFold(state, "available") || state == string(types.AttachmentStatusAttached)
+}
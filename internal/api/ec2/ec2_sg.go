@@ -2,6 +2,7 @@ package ec2
 
 import (
 	"context"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -21,7 +22,7 @@ func CreateDescribeSecurityGroupsInput(ids, names []string, filters []types.Filt
 	if names != nil {
 		f = append(f, types.Filter{
 			Name:   aws.String("group-name"),
-			Values: ids,
+			Values: names,
 		})
 	}
 	if len(filters) > 0 {
@@ -32,6 +33,83 @@ func CreateDescribeSecurityGroupsInput(ids, names []string, filters []types.Filt
 	}
 }
 
+var SecurityGroupLister = &Lister[types.SecurityGroup]{
+	Name:    "DescribeSecurityGroups",
+	Filters: securityGroupFilterNames,
+	List:    listSecurityGroups,
+}
+
+func listSecurityGroups(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.SecurityGroup, *string, error) {
+	input := CreateDescribeSecurityGroupsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeSecurityGroups(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !in.Unused {
+		return o.SecurityGroups, o.NextToken, nil
+	}
+	used, err := getEc2SecurityGroupsInUse(ctx, l, client, region, o.SecurityGroups)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sgs []types.SecurityGroup
+	for _, sg := range o.SecurityGroups {
+		if !used[aws.ToString(sg.GroupId)] && aws.ToString(sg.GroupName) != "default" {
+			sgs = append(sgs, sg)
+		}
+	}
+	return sgs, o.NextToken, nil
+}
+
+// getEc2SecurityGroupsInUse returns the ids of the groups attached to any network interface.
+// Interfaces of instances, load balancers, Lambda functions, RDS and other services are all covered.
+// The group ids are requested in chunks of 200, the maximum number of filter values.
+func getEc2SecurityGroupsInUse(ctx context.Context, l *rate.Limiter, client IEc2Client, region string, sgs []types.SecurityGroup) (map[string]bool, error) {
+	used := make(map[string]bool)
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	for i := 0; i < len(sgs); i += 200 {
+		var ids []string
+		for _, sg := range sgs[i:min(i+200, len(sgs))] {
+			ids = append(ids, aws.ToString(sg.GroupId))
+		}
+		var token *string
+		for {
+			if err := l.Wait(ctx); err != nil {
+				return nil, err
+			}
+			input := &ec2.DescribeNetworkInterfacesInput{
+				Filters: []types.Filter{
+					{
+						Name:   aws.String("group-id"),
+						Values: ids,
+					},
+				},
+				NextToken: token,
+			}
+			o, err := client.DescribeNetworkInterfaces(ctx, input, opt)
+			if err != nil {
+				return nil, err
+			}
+			for _, eni := range o.NetworkInterfaces {
+				for _, g := range eni.Groups {
+					used[aws.ToString(g.GroupId)] = true
+				}
+			}
+			token = o.NextToken
+			if token == nil {
+				break
+			}
+		}
+	}
+	return used, nil
+}
+
 type SecurityGroupInfo struct {
 	SecurityGroupId   string
 	SecurityGroupName string
@@ -40,7 +118,7 @@ type SecurityGroupInfo struct {
 	Region            string
 }
 
-func GetSecurityGroupInfo(ich chan<- SecurityGroupInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc) error {
+func GetSecurityGroupInfo(ich chan<- SecurityGroupInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, n *Namer) error {
 	for _, sg := range sgs {
 		vpcId := aws.ToString(sg.VpcId)
 		vpc, err := findEc2VpcById(vpcId, vpcs)
@@ -51,7 +129,7 @@ func GetSecurityGroupInfo(ich chan<- SecurityGroupInfo, sgs []types.SecurityGrou
 			SecurityGroupId:   aws.ToString(sg.GroupId),
 			SecurityGroupName: aws.ToString(sg.GroupName),
 			VpcId:             vpcId,
-			VpcName:           getEc2NameTagValue(vpc.Tags),
+			VpcName:           n.name(vpc.Tags, vpc.VpcId),
 			Region:            region,
 		}
 	}
@@ -69,6 +147,7 @@ type SecurityGroupPermissionsInfo struct {
 	ToPort            int32
 	AddressType       string
 	CidrBlock         string
+	PrefixList        string
 	Region            string
 }
 
@@ -107,20 +186,22 @@ func FetchDataForSecurityGroupPermissionsInfo(ctx context.Context, l *rate.Limit
 	return vpcs, upls, mpls, nil
 }
 
-func GetSecurityGroupPermissionsInfo(ich chan<- SecurityGroupPermissionsInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList) error {
+// GetSecurityGroupPermissionsInfo sends the rules of the security groups.
+// Rules referencing a prefix list are expanded to its CIDR blocks when the entries are not nil.
+func GetSecurityGroupPermissionsInfo(ich chan<- SecurityGroupPermissionsInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, n *Namer) error {
 	for _, sg := range sgs {
-		if err := appendToSecurityGroupPermissionsInfo(ich, sg, vpcs, upls, mpls, false, region); err != nil {
+		if err := appendToSecurityGroupPermissionsInfo(ich, sg, vpcs, upls, mpls, ples, false, region, n); err != nil {
 			return err
 		}
-		if err := appendToSecurityGroupPermissionsInfo(ich, sg, vpcs, upls, mpls, true, region); err != nil {
+		if err := appendToSecurityGroupPermissionsInfo(ich, sg, vpcs, upls, mpls, ples, true, region, n); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func appendToSecurityGroupPermissionsInfo(ich chan<- SecurityGroupPermissionsInfo, item types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, isEgress bool, region string) error {
-	perms, err := handleSecurityGroupPermissionsInfo(item, vpcs, upls, mpls, isEgress, region)
+func appendToSecurityGroupPermissionsInfo(ich chan<- SecurityGroupPermissionsInfo, item types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, isEgress bool, region string, n *Namer) error {
+	perms, err := handleSecurityGroupPermissionsInfo(item, vpcs, upls, mpls, ples, isEgress, region, n)
 	if err != nil {
 		return err
 	}
@@ -130,7 +211,7 @@ func appendToSecurityGroupPermissionsInfo(ich chan<- SecurityGroupPermissionsInf
 	return nil
 }
 
-func handleSecurityGroupPermissionsInfo(item types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, isEgress bool, region string) ([]SecurityGroupPermissionsInfo, error) {
+func handleSecurityGroupPermissionsInfo(item types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, isEgress bool, region string, n *Namer) ([]SecurityGroupPermissionsInfo, error) {
 	var ipPermissions []types.IpPermission
 	var flowDirection string
 	var info []SecurityGroupPermissionsInfo
@@ -151,7 +232,7 @@ func handleSecurityGroupPermissionsInfo(item types.SecurityGroup, vpcs map[strin
 			SecurityGroupId:   aws.ToString(item.GroupId),
 			SecurityGroupName: aws.ToString(item.GroupName),
 			VpcId:             vpcId,
-			VpcName:           getEc2NameTagValue(vpc.Tags),
+			VpcName:           n.name(vpc.Tags, vpc.VpcId),
 			FlowDirection:     flowDirection,
 			IpProtocol:        aws.ToString(ipPermission.IpProtocol),
 			FromPort:          aws.ToInt32(ipPermission.FromPort),
@@ -178,10 +259,425 @@ func handleSecurityGroupPermissionsInfo(item types.SecurityGroup, vpcs map[strin
 			if err != nil {
 				return nil, err
 			}
+			pl := aws.ToString(prefixList.PrefixListId) + "/" + name
+			if cidrs, ok := ples.expand(aws.ToString(prefixList.PrefixListId)); ok {
+				for _, cidr := range cidrs {
+					obj.AddressType = getEc2CidrAddressType(cidr)
+					obj.CidrBlock = cidr
+					obj.PrefixList = pl
+					info = append(info, obj)
+				}
+				obj.PrefixList = ""
+				continue
+			}
 			obj.AddressType = addressTypePrefixList.String()
-			obj.CidrBlock = aws.ToString(prefixList.PrefixListId) + "/" + name
+			obj.CidrBlock = pl
 			info = append(info, obj)
 		}
 	}
 	return info, nil
 }
+
+type SecurityGroupAuditInfo struct {
+	SecurityGroupId   string
+	SecurityGroupName string
+	VpcId             string
+	VpcName           string
+	Severity          string
+	Finding           string
+	IpProtocol        string
+	FromPort          int32
+	ToPort            int32
+	AddressType       string
+	CidrBlock         string
+	Region            string
+}
+
+// GetSecurityGroupAuditInfo sends a row for each finding of the ingress rules of the security groups.
+// The data is fetched by FetchDataForSecurityGroupPermissionsInfo.
+func GetSecurityGroupAuditInfo(ich chan<- SecurityGroupAuditInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, ples PrefixListEntries, rules *AuditRules, n *Namer) error {
+	for _, sg := range sgs {
+		perms, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, ples, false, region, n)
+		if err != nil {
+			return err
+		}
+		for _, perm := range perms {
+			for _, res := range rules.audit(perm) {
+				ich <- SecurityGroupAuditInfo{
+					SecurityGroupId:   perm.SecurityGroupId,
+					SecurityGroupName: perm.SecurityGroupName,
+					VpcId:             perm.VpcId,
+					VpcName:           perm.VpcName,
+					Severity:          res.Severity,
+					Finding:           res.Finding,
+					IpProtocol:        perm.IpProtocol,
+					FromPort:          perm.FromPort,
+					ToPort:            perm.ToPort,
+					AddressType:       perm.AddressType,
+					CidrBlock:         perm.CidrBlock,
+					Region:            perm.Region,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type SecurityGroupRedundancyInfo struct {
+	SecurityGroupId    string
+	SecurityGroupName  string
+	VpcId              string
+	VpcName            string
+	FlowDirection      string
+	Finding            string
+	IpProtocol         string
+	FromPort           int32
+	ToPort             int32
+	AddressType        string
+	CidrBlock          string
+	CoveringIpProtocol string
+	CoveringFromPort   int32
+	CoveringToPort     int32
+	CoveringCidrBlock  string
+	Suggestion         string
+	Region             string
+}
+
+// GetSecurityGroupRedundancyInfo sends a row for each rule made redundant by another rule of the same group.
+// The data is fetched by FetchDataForSecurityGroupPermissionsInfo.
+func GetSecurityGroupRedundancyInfo(ich chan<- SecurityGroupRedundancyInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, n *Namer) error {
+	for _, sg := range sgs {
+		perms, err := handleSecurityGroupRules(sg, vpcs, upls, mpls, region, n)
+		if err != nil {
+			return err
+		}
+		for _, r := range findEc2RedundantRules(perms) {
+			ich <- SecurityGroupRedundancyInfo{
+				SecurityGroupId:    r.Rule.SecurityGroupId,
+				SecurityGroupName:  r.Rule.SecurityGroupName,
+				VpcId:              r.Rule.VpcId,
+				VpcName:            r.Rule.VpcName,
+				FlowDirection:      r.Rule.FlowDirection,
+				Finding:            r.Finding,
+				IpProtocol:         r.Rule.IpProtocol,
+				FromPort:           r.Rule.FromPort,
+				ToPort:             r.Rule.ToPort,
+				AddressType:        r.Rule.AddressType,
+				CidrBlock:          r.Rule.CidrBlock,
+				CoveringIpProtocol: r.Cover.IpProtocol,
+				CoveringFromPort:   r.Cover.FromPort,
+				CoveringToPort:     r.Cover.ToPort,
+				CoveringCidrBlock:  r.Cover.CidrBlock,
+				Suggestion:         r.Suggestion,
+				Region:             region,
+			}
+		}
+	}
+	return nil
+}
+
+// handleSecurityGroupRules returns the ingress rules of the group followed by its egress rules.
+func handleSecurityGroupRules(sg types.SecurityGroup, vpcs map[string]types.Vpc, upls map[string]types.PrefixList, mpls map[string]types.ManagedPrefixList, region string, n *Namer) ([]SecurityGroupPermissionsInfo, error) {
+	ingress, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, nil, false, region, n)
+	if err != nil {
+		return nil, err
+	}
+	egress, err := handleSecurityGroupPermissionsInfo(sg, vpcs, upls, mpls, nil, true, region, n)
+	if err != nil {
+		return nil, err
+	}
+	return append(ingress, egress...), nil
+}
+
+type SecurityGroupUsageInfo struct {
+	SecurityGroupId      string
+	SecurityGroupName    string
+	VpcId                string
+	VpcName              string
+	Owner                string
+	NetworkInterfaceId   string
+	NetworkInterfaceName string
+	InterfaceType        types.NetworkInterfaceType
+	Description          string
+	InstanceId           string
+	InstanceName         string
+	Region               string
+}
+
+func FetchDataForSecurityGroupUsageInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.NetworkInterface, map[string]types.Instance, error) {
+	vpcs := make(map[string]types.Vpc)
+	enis := make(map[string]types.NetworkInterface)
+	instances := make(map[string]types.Instance)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		enis, err = client.FetchNetworkInterfaces(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		instances, err = client.FetchInstances(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+	return vpcs, enis, instances, nil
+}
+
+// GetSecurityGroupUsageInfo sends the groups with the network interfaces attached to them and what owns each interface:
+// an instance, a load balancer, a Lambda function, an RDS instance or another service.
+// The description of the interface names the load balancer or function. Unused groups are sent once without an interface.
+func GetSecurityGroupUsageInfo(ich chan<- SecurityGroupUsageInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, enis map[string]types.NetworkInterface, instances map[string]types.Instance, n *Namer) error {
+	usage := make(map[string][]types.NetworkInterface)
+	for _, eni := range enis {
+		for _, g := range eni.Groups {
+			id := aws.ToString(g.GroupId)
+			usage[id] = append(usage[id], eni)
+		}
+	}
+	for _, sg := range sgs {
+		vpcId := aws.ToString(sg.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		obj := SecurityGroupUsageInfo{
+			SecurityGroupId:   aws.ToString(sg.GroupId),
+			SecurityGroupName: aws.ToString(sg.GroupName),
+			VpcId:             vpcId,
+			VpcName:           n.name(vpc.Tags, vpc.VpcId),
+			Region:            region,
+		}
+		attached := usage[obj.SecurityGroupId]
+		if len(attached) == 0 {
+			ich <- obj
+			continue
+		}
+		for _, eni := range attached {
+			row := obj
+			row.Owner = getEc2NetworkInterfaceOwner(eni)
+			row.NetworkInterfaceId = aws.ToString(eni.NetworkInterfaceId)
+			row.NetworkInterfaceName = n.name(eni.TagSet, eni.PrivateDnsName, eni.NetworkInterfaceId)
+			row.InterfaceType = eni.InterfaceType
+			row.Description = aws.ToString(eni.Description)
+			if a := eni.Attachment; a != nil {
+				row.InstanceId = aws.ToString(a.InstanceId)
+				if i, ok := instances[row.InstanceId]; ok {
+					row.InstanceName = n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+				}
+			}
+			ich <- row
+		}
+	}
+	return nil
+}
+
+type SecurityGroupReferenceInfo struct {
+	SecurityGroupId        string
+	SecurityGroupName      string
+	VpcId                  string
+	VpcName                string
+	ReferencingGroupId     string
+	ReferencingGroupName   string
+	ReferencingVpcId       string
+	FlowDirection          string
+	IpProtocol             string
+	FromPort               int32
+	ToPort                 int32
+	VpcPeeringConnectionId string
+	PeeringStatus          string
+	Stale                  bool
+	MissingGroupId         string
+	Region                 string
+}
+
+// SecurityGroupReferences holds the rules of the groups of a region referencing other groups.
+type SecurityGroupReferences struct {
+	// ByGroup are the rules referencing each group of the region, by the id of the referenced group.
+	ByGroup map[string][]SecurityGroupReferenceInfo
+
+	// Missing are the stale rules referencing groups not found in the region, deleted or of a VPC
+	// no longer peered, by the id of the group the rules belong to.
+	Missing map[string][]SecurityGroupReferenceInfo
+}
+
+// FetchDataForSecurityGroupReferenceInfo fetches the VPCs and the references between the groups of the region.
+// The stale rules are requested once per VPC by DescribeStaleSecurityGroups.
+func FetchDataForSecurityGroupReferenceInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, *SecurityGroupReferences, error) {
+	vpcs := make(map[string]types.Vpc)
+	segs := make(map[string]types.SecurityGroup)
+	var ssgs []types.StaleSecurityGroup
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vpcs, err = client.FetchVpcs(ctx, region)
+		if err != nil {
+			return err
+		}
+		for _, vpc := range vpcs {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			s, err := client.GetStaleSecurityGroups(ctx, region, vpc.VpcId)
+			if err != nil {
+				return err
+			}
+			ssgs = append(ssgs, s...)
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		segs, err = client.FetchSecurityGroups(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return vpcs, getEc2SecurityGroupReferenceMap(segs, ssgs), nil
+}
+
+// getEc2SecurityGroupReferenceMap indexes the rules of the groups referencing other groups. The stale rules
+// mark the matching references, or are kept as missing when the group they reference is not in the region.
+func getEc2SecurityGroupReferenceMap(segs map[string]types.SecurityGroup, ssgs []types.StaleSecurityGroup) *SecurityGroupReferences {
+	refs := &SecurityGroupReferences{
+		ByGroup: make(map[string][]SecurityGroupReferenceInfo),
+		Missing: make(map[string][]SecurityGroupReferenceInfo),
+	}
+	for _, sg := range segs {
+		for _, ref := range getEc2SecurityGroupReferences(sg.IpPermissions, sg, Ingress) {
+			refs.ByGroup[ref.SecurityGroupId] = append(refs.ByGroup[ref.SecurityGroupId], ref)
+		}
+		for _, ref := range getEc2SecurityGroupReferences(sg.IpPermissionsEgress, sg, Egress) {
+			refs.ByGroup[ref.SecurityGroupId] = append(refs.ByGroup[ref.SecurityGroupId], ref)
+		}
+	}
+	for _, ssg := range ssgs {
+		stale := slices.Concat(
+			getEc2StaleSecurityGroupReferences(ssg.StaleIpPermissions, ssg, Ingress),
+			getEc2StaleSecurityGroupReferences(ssg.StaleIpPermissionsEgress, ssg, Egress),
+		)
+		for _, ref := range stale {
+			if _, ok := segs[ref.SecurityGroupId]; !ok {
+				ref.MissingGroupId = ref.SecurityGroupId
+				ref.ReferencingGroupId, ref.ReferencingGroupName, ref.ReferencingVpcId = "", "", ""
+				refs.Missing[aws.ToString(ssg.GroupId)] = append(refs.Missing[aws.ToString(ssg.GroupId)], ref)
+				continue
+			}
+			// A group of a VPC no longer peered still exists and may already be referenced by the rule.
+			i := slices.IndexFunc(refs.ByGroup[ref.SecurityGroupId], func(r SecurityGroupReferenceInfo) bool {
+				return r.ReferencingGroupId == ref.ReferencingGroupId &&
+					r.FlowDirection == ref.FlowDirection &&
+					r.IpProtocol == ref.IpProtocol &&
+					r.FromPort == ref.FromPort &&
+					r.ToPort == ref.ToPort
+			})
+			if i >= 0 {
+				refs.ByGroup[ref.SecurityGroupId][i].Stale = true
+				continue
+			}
+			refs.ByGroup[ref.SecurityGroupId] = append(refs.ByGroup[ref.SecurityGroupId], ref)
+		}
+	}
+	return refs
+}
+
+// GetSecurityGroupReferenceInfo sends the groups with every rule of the groups in the region referencing them.
+// The stale rules, referencing deleted groups or groups of VPCs no longer peered, are found by DescribeStaleSecurityGroups
+// and set Stale. A stale rule referencing a group not in the region is sent on the group the rule belongs to,
+// with the referenced group as MissingGroupId and no referencing group. Groups with none of them are sent once.
+func GetSecurityGroupReferenceInfo(ich chan<- SecurityGroupReferenceInfo, sgs []types.SecurityGroup, region string, vpcs map[string]types.Vpc, refs *SecurityGroupReferences, n *Namer) error {
+	for _, sg := range sgs {
+		vpcId := aws.ToString(sg.VpcId)
+		vpc, err := findEc2VpcById(vpcId, vpcs)
+		if err != nil {
+			return err
+		}
+		obj := SecurityGroupReferenceInfo{
+			SecurityGroupId:   aws.ToString(sg.GroupId),
+			SecurityGroupName: aws.ToString(sg.GroupName),
+			VpcId:             vpcId,
+			VpcName:           n.name(vpc.Tags, vpc.VpcId),
+			Region:            region,
+		}
+		rows := slices.Concat(refs.ByGroup[obj.SecurityGroupId], refs.Missing[obj.SecurityGroupId])
+		if len(rows) == 0 {
+			ich <- obj
+		}
+		for _, ref := range rows {
+			ref.SecurityGroupId = obj.SecurityGroupId
+			ref.SecurityGroupName = obj.SecurityGroupName
+			ref.VpcId = obj.VpcId
+			ref.VpcName = obj.VpcName
+			ref.Region = region
+			ich <- ref
+		}
+	}
+	return nil
+}
+
+func getEc2SecurityGroupReferences(perms []types.IpPermission, sg types.SecurityGroup, direction flowDirection) []SecurityGroupReferenceInfo {
+	var refs []SecurityGroupReferenceInfo
+	for _, perm := range perms {
+		for _, pair := range perm.UserIdGroupPairs {
+			refs = append(refs, SecurityGroupReferenceInfo{
+				SecurityGroupId:        aws.ToString(pair.GroupId),
+				ReferencingGroupId:     aws.ToString(sg.GroupId),
+				ReferencingGroupName:   aws.ToString(sg.GroupName),
+				ReferencingVpcId:       aws.ToString(sg.VpcId),
+				FlowDirection:          direction.String(),
+				IpProtocol:             aws.ToString(perm.IpProtocol),
+				FromPort:               aws.ToInt32(perm.FromPort),
+				ToPort:                 aws.ToInt32(perm.ToPort),
+				VpcPeeringConnectionId: aws.ToString(pair.VpcPeeringConnectionId),
+				PeeringStatus:          aws.ToString(pair.PeeringStatus),
+			})
+		}
+	}
+	return refs
+}
+
+func getEc2StaleSecurityGroupReferences(perms []types.StaleIpPermission, ssg types.StaleSecurityGroup, direction flowDirection) []SecurityGroupReferenceInfo {
+	var refs []SecurityGroupReferenceInfo
+	for _, perm := range perms {
+		for _, pair := range perm.UserIdGroupPairs {
+			refs = append(refs, SecurityGroupReferenceInfo{
+				SecurityGroupId:        aws.ToString(pair.GroupId),
+				SecurityGroupName:      aws.ToString(pair.GroupName),
+				VpcId:                  aws.ToString(pair.VpcId),
+				ReferencingGroupId:     aws.ToString(ssg.GroupId),
+				ReferencingGroupName:   aws.ToString(ssg.GroupName),
+				ReferencingVpcId:       aws.ToString(ssg.VpcId),
+				FlowDirection:          direction.String(),
+				IpProtocol:             aws.ToString(perm.IpProtocol),
+				FromPort:               aws.ToInt32(perm.FromPort),
+				ToPort:                 aws.ToInt32(perm.ToPort),
+				VpcPeeringConnectionId: aws.ToString(pair.VpcPeeringConnectionId),
+				PeeringStatus:          aws.ToString(pair.PeeringStatus),
+				Stale:                  true,
+			})
+		}
+	}
+	return refs
+}
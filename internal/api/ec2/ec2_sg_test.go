@@ -0,0 +1,114 @@
+package ec2
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestGetSecurityGroupReferenceInfo(t *testing.T) {
+	group := func(id string, perms ...types.IpPermission) types.SecurityGroup {
+		return types.SecurityGroup{
+			GroupId:       aws.String(id),
+			GroupName:     aws.String(id + "-name"),
+			VpcId:         aws.String("vpc-1"),
+			IpPermissions: perms,
+		}
+	}
+	perm := func(port int32, target string) types.IpPermission {
+		return types.IpPermission{
+			IpProtocol:       aws.String("tcp"),
+			FromPort:         aws.Int32(port),
+			ToPort:           aws.Int32(port),
+			UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String(target)}},
+		}
+	}
+	stale := func(id string, port int32, target string) types.StaleSecurityGroup {
+		return types.StaleSecurityGroup{
+			GroupId: aws.String(id),
+			VpcId:   aws.String("vpc-1"),
+			StaleIpPermissions: []types.StaleIpPermission{
+				{
+					IpProtocol:       aws.String("tcp"),
+					FromPort:         aws.Int32(port),
+					ToPort:           aws.Int32(port),
+					UserIdGroupPairs: []types.UserIdGroupPair{{GroupId: aws.String(target)}},
+				},
+			},
+		}
+	}
+	segs := map[string]types.SecurityGroup{
+		"sg-a": group("sg-a", perm(443, "sg-z")),
+		"sg-b": group("sg-b", perm(22, "sg-a"), perm(80, "sg-a")),
+		"sg-c": group("sg-c"),
+	}
+	ssgs := []types.StaleSecurityGroup{
+		stale("sg-a", 443, "sg-z"),
+		stale("sg-b", 22, "sg-a"),
+	}
+	vpcs := map[string]types.Vpc{"vpc-1": {VpcId: aws.String("vpc-1")}}
+	refs := getEc2SecurityGroupReferenceMap(segs, ssgs)
+
+	type row struct {
+		SecurityGroupId, ReferencingGroupId string
+		Port                                int32
+		Stale                               bool
+		MissingGroupId                      string
+	}
+	tests := []struct {
+		name string
+		sgs  []string
+		want []row
+	}{
+		{
+			name: "referenced with a stale rule",
+			sgs:  []string{"sg-a"},
+			want: []row{
+				{"sg-a", "", 443, true, "sg-z"},
+				{"sg-a", "sg-b", 22, true, ""},
+				{"sg-a", "sg-b", 80, false, ""},
+			},
+		},
+		{
+			name: "missing target not reported on unselected group",
+			sgs:  []string{"sg-b"},
+			want: []row{
+				{"sg-b", "", 0, false, ""},
+			},
+		},
+		{
+			name: "not referenced",
+			sgs:  []string{"sg-c"},
+			want: []row{
+				{"sg-c", "", 0, false, ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sgs []types.SecurityGroup
+			for _, id := range tt.sgs {
+				sgs = append(sgs, segs[id])
+			}
+			ich := make(chan SecurityGroupReferenceInfo, 16)
+			if err := GetSecurityGroupReferenceInfo(ich, sgs, "ap-northeast-1", vpcs, refs, nil); err != nil {
+				t.Fatal(err)
+			}
+			close(ich)
+			var got []row
+			for info := range ich {
+				got = append(got, row{info.SecurityGroupId, info.ReferencingGroupId, info.FromPort, info.Stale, info.MissingGroupId})
+			}
+			slices.SortFunc(got, func(a, b row) int {
+				return cmp.Or(cmp.Compare(a.ReferencingGroupId, b.ReferencingGroupId), cmp.Compare(a.Port, b.Port))
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSecurityGroupReferenceInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
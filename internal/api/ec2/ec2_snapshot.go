@@ -0,0 +1,254 @@
+package ec2
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeSnapshotsInput(ids, names []string, filters []types.Filter, defaultFilter bool) *ec2.DescribeSnapshotsInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("snapshot-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	input := &ec2.DescribeSnapshotsInput{
+		Filters: f,
+	}
+	if defaultFilter {
+		input.OwnerIds = []string{"self"}
+	}
+	return input
+}
+
+var SnapshotLister = &Lister[types.Snapshot]{
+	Name:    "DescribeSnapshots",
+	Filters: snapshotFilterNames,
+	List:    listSnapshots,
+	Named: namedBy(func(item types.Snapshot) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listSnapshots(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Snapshot, *string, error) {
+	input := CreateDescribeSnapshotsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeSnapshots(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if in.OlderThan <= 0 {
+		return o.Snapshots, o.NextToken, nil
+	}
+	// The API has no filter on the start time, so the age is checked on the items.
+	before := time.Now().Add(-in.OlderThan)
+	var snps []types.Snapshot
+	for _, snp := range o.Snapshots {
+		if snp.StartTime != nil && snp.StartTime.Before(before) {
+			snps = append(snps, snp)
+		}
+	}
+	return snps, o.NextToken, nil
+}
+
+type SnapshotInfo struct {
+	SnapshotId   string
+	SnapshotName string
+	State        types.SnapshotState
+	VolumeId     string
+	VolumeSize   int32
+	Encrypted    bool
+	KmsKeyId     string
+	StorageTier  types.StorageTier
+	StartTime    string
+	AgeDays      int
+	Description  string
+	OwnerId      string
+	Region       string
+}
+
+func GetSnapshotInfo(ich chan<- SnapshotInfo, snps []types.Snapshot, region string, now time.Time, n *Namer) {
+	for _, snp := range snps {
+		ich <- SnapshotInfo{
+			SnapshotId:   aws.ToString(snp.SnapshotId),
+			SnapshotName: n.name(snp.Tags, snp.SnapshotId),
+			State:        snp.State,
+			VolumeId:     aws.ToString(snp.VolumeId),
+			VolumeSize:   aws.ToInt32(snp.VolumeSize),
+			Encrypted:    aws.ToBool(snp.Encrypted),
+			KmsKeyId:     aws.ToString(snp.KmsKeyId),
+			StorageTier:  snp.StorageTier,
+			StartTime:    formatTime(snp.StartTime),
+			AgeDays:      ageDays(snp.StartTime, now),
+			Description:  aws.ToString(snp.Description),
+			OwnerId:      aws.ToString(snp.OwnerId),
+			Region:       region,
+		}
+	}
+}
+
+type SnapshotLineageInfo struct {
+	SnapshotId   string
+	SnapshotName string
+	VolumeId     string
+	VolumeName   string
+	VolumeExists bool
+	ImageId      string
+	ImageName    string
+	InstanceId   string
+	InstanceName string
+	Region       string
+}
+
+func FetchDataForSnapshotLineageInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Volume, map[string]types.Image, map[string]types.Instance, error) {
+	var vols map[string]types.Volume
+	var imgs map[string]types.Image
+	var instances map[string]types.Instance
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vols, err = client.FetchVolumes(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		imgs, err = client.FetchOwnedImages(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		instances, err = client.FetchInstances(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, nil, err
+	}
+	return vols, imgs, instances, nil
+}
+
+// GetSnapshotLineageInfo sends the snapshots with their source volumes, the images
+// whose block devices reference them and the instances launched from those images.
+func GetSnapshotLineageInfo(ich chan<- SnapshotLineageInfo, snps []types.Snapshot, region string, vols map[string]types.Volume, imgs map[string]types.Image, instances map[string]types.Instance, n *Namer) {
+	imgsBySnapshot := make(map[string][]types.Image)
+	for _, img := range imgs {
+		for _, bdm := range img.BlockDeviceMappings {
+			if bdm.Ebs != nil && bdm.Ebs.SnapshotId != nil {
+				id := aws.ToString(bdm.Ebs.SnapshotId)
+				imgsBySnapshot[id] = append(imgsBySnapshot[id], img)
+			}
+		}
+	}
+	instancesByImage := make(map[string][]types.Instance)
+	for _, i := range instances {
+		id := aws.ToString(i.ImageId)
+		instancesByImage[id] = append(instancesByImage[id], i)
+	}
+	for _, snp := range snps {
+		obj := SnapshotLineageInfo{
+			SnapshotId:   aws.ToString(snp.SnapshotId),
+			SnapshotName: n.name(snp.Tags, snp.SnapshotId),
+			VolumeId:     aws.ToString(snp.VolumeId),
+			Region:       region,
+		}
+		if vol := findEc2VolumeById(obj.VolumeId, vols); vol != nil {
+			obj.VolumeName = n.name(vol.Tags, vol.VolumeId)
+			obj.VolumeExists = true
+		}
+		if len(imgsBySnapshot[obj.SnapshotId]) == 0 {
+			ich <- obj
+			continue
+		}
+		for _, img := range imgsBySnapshot[obj.SnapshotId] {
+			row := obj
+			row.ImageId = aws.ToString(img.ImageId)
+			row.ImageName = aws.ToString(img.Name)
+			if len(instancesByImage[row.ImageId]) == 0 {
+				ich <- row
+				continue
+			}
+			for _, i := range instancesByImage[row.ImageId] {
+				row.InstanceId = aws.ToString(i.InstanceId)
+				row.InstanceName = n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+				ich <- row
+			}
+		}
+	}
+}
+
+type SnapshotPermissionInfo struct {
+	SnapshotId   string
+	SnapshotName string
+	OwnerId      string
+	Sharing      string
+	UserId       string
+	Group        types.PermissionGroup
+	Region       string
+}
+
+// GetSnapshotPermissionInfo sends the snapshots with their createVolumePermission entries,
+// flagging the ones shared with every account or with other accounts.
+func GetSnapshotPermissionInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, ich chan<- SnapshotPermissionInfo, snps []types.Snapshot, region string, n *Namer) error {
+	for _, snp := range snps {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		perms, err := client.GetSnapshotCreateVolumePermissions(ctx, region, snp.SnapshotId)
+		if err != nil {
+			return err
+		}
+		obj := SnapshotPermissionInfo{
+			SnapshotId:   aws.ToString(snp.SnapshotId),
+			SnapshotName: n.name(snp.Tags, snp.SnapshotId),
+			OwnerId:      aws.ToString(snp.OwnerId),
+			Region:       region,
+		}
+		if len(perms) == 0 {
+			obj.Sharing = sharingTypePrivate.String()
+			ich <- obj
+			continue
+		}
+		for _, perm := range perms {
+			row := obj
+			row.UserId = aws.ToString(perm.UserId)
+			row.Group = perm.Group
+			switch {
+			case perm.Group == types.PermissionGroupAll:
+				row.Sharing = sharingTypePublic.String()
+			case row.UserId != "" && row.UserId != row.OwnerId:
+				row.Sharing = sharingTypeCrossAccount.String()
+			default:
+				row.Sharing = sharingTypePrivate.String()
+			}
+			ich <- row
+		}
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package ec2
 
 import (
 	"context"
+	"math"
+	"net/netip"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -38,6 +40,28 @@ func CreateDescribeSubnetsInput(ids, names []string, filters []types.Filter, def
 	}
 }
 
+var SubnetLister = &Lister[types.Subnet]{
+	Name:    "DescribeSubnets",
+	Filters: subnetFilterNames,
+	List:    listSubnets,
+	Named: namedBy(func(item types.Subnet) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listSubnets(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Subnet, *string, error) {
+	input := CreateDescribeSubnetsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeSubnets(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.Subnets, o.NextToken, nil
+}
+
 type SubnetInfo struct {
 	SubnetId                string
 	SubnetName              string
@@ -50,29 +74,45 @@ type SubnetInfo struct {
 	AddressType             string
 	CidrBlock               string
 	Region                  string
+	UsedIpAddressCount      int32
+	TotalIpAddressCount     int32
+	Utilization             float64
 }
 
-func GetSubnetInfo(ich chan<- SubnetInfo, subnets []types.Subnet, region string, vpcs map[string]types.Vpc) error {
+// GetSubnetInfo sends the subnets with the IPv4 capacity: the addresses of the CIDR block less the five reserved by AWS,
+// the ones in use and their percentage. The capacity is left empty on the IPv6 rows. Subnets used less than
+// minUtilization percent are skipped, and so are IPv6-only subnets when minUtilization is set.
+func GetSubnetInfo(ich chan<- SubnetInfo, subnets []types.Subnet, region string, vpcs map[string]types.Vpc, minUtilization float64, n *Namer) error {
 	for _, subnet := range subnets {
 		vpcId := aws.ToString(subnet.VpcId)
 		vpc, err := findEc2VpcById(vpcId, vpcs)
 		if err != nil {
 			return err
 		}
+		available := aws.ToInt32(subnet.AvailableIpAddressCount)
+		total := getEc2SubnetIpAddressCount(aws.ToString(subnet.CidrBlock))
+		utilization := getEc2Utilization(total-available, total)
+		if utilization < minUtilization {
+			continue
+		}
 		obj := SubnetInfo{
 			SubnetId:                aws.ToString(subnet.SubnetId),
-			SubnetName:              getEc2NameTagValue(subnet.Tags),
+			SubnetName:              n.name(subnet.Tags, subnet.SubnetId),
 			AvailabilityZone:        aws.ToString(subnet.AvailabilityZone),
-			AvailableIpAddressCount: aws.ToInt32(subnet.AvailableIpAddressCount),
+			AvailableIpAddressCount: available,
 			DefaultForAz:            aws.ToBool(subnet.DefaultForAz),
 			State:                   subnet.State,
 			VpcId:                   vpcId,
-			VpcName:                 getEc2NameTagValue(vpc.Tags),
+			VpcName:                 n.name(vpc.Tags, vpc.VpcId),
 			AddressType:             addressTypeIpv4.String(),
 			CidrBlock:               aws.ToString(subnet.CidrBlock),
 			Region:                  region,
+			UsedIpAddressCount:      total - available,
+			TotalIpAddressCount:     total,
+			Utilization:             utilization,
 		}
 		ich <- obj
+		obj.UsedIpAddressCount, obj.TotalIpAddressCount, obj.Utilization = 0, 0, 0
 		for _, assoc := range subnet.Ipv6CidrBlockAssociationSet {
 			obj.AddressType = addressTypeIpv6.String()
 			obj.CidrBlock = aws.ToString(assoc.Ipv6CidrBlock)
@@ -94,12 +134,16 @@ type SubnetRouteInfo struct {
 	Destination      string
 	TargetType       string
 	Target           string
+	TargetName       string
+	TargetState      string
 	Region           string
+	PrefixList       string
 }
 
-func FetchDataForSubnetRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.RouteTable, error) {
+func FetchDataForSubnetRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.RouteTable, *Gateways, error) {
 	vpcs := make(map[string]types.Vpc)
 	rtbs := make(map[string]types.RouteTable)
+	var gws *Gateways
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
 		if err := l.Wait(ctx); err != nil {
@@ -117,13 +161,18 @@ func FetchDataForSubnetRouteInfo(ctx context.Context, l *rate.Limiter, client IE
 		rtbs, err = client.FetchRouteTables(ctx, region)
 		return err
 	})
+	eg.Go(func() error {
+		var err error
+		gws, err = FetchGateways(ctx, l, client, region)
+		return err
+	})
 	if err := eg.Wait(); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return vpcs, rtbs, nil
+	return vpcs, rtbs, gws, nil
 }
 
-func GetSubnetRouteInfo(ich chan<- SubnetRouteInfo, subnets []types.Subnet, region string, vpcs map[string]types.Vpc, rtbs map[string]types.RouteTable) error {
+func GetSubnetRouteInfo(ich chan<- SubnetRouteInfo, subnets []types.Subnet, region string, vpcs map[string]types.Vpc, rtbs map[string]types.RouteTable, gws *Gateways, ples PrefixListEntries, n *Namer) error {
 	for _, subnet := range subnets {
 		vpcId := aws.ToString(subnet.VpcId)
 		vpc, err := findEc2VpcById(vpcId, vpcs)
@@ -137,29 +186,137 @@ func GetSubnetRouteInfo(ich chan<- SubnetRouteInfo, subnets []types.Subnet, regi
 		rtbId := aws.ToString(rtb.RouteTableId)
 		obj := SubnetRouteInfo{
 			SubnetId:         aws.ToString(subnet.SubnetId),
-			SubnetName:       getEc2NameTagValue(subnet.Tags),
+			SubnetName:       n.name(subnet.Tags, subnet.SubnetId),
 			AvailabilityZone: aws.ToString(subnet.AvailabilityZone),
 			VpcId:            vpcId,
-			VpcName:          getEc2NameTagValue(vpc.Tags),
+			VpcName:          n.name(vpc.Tags, vpc.VpcId),
 			RouteTableId:     rtbId,
-			RouteTableName:   getEc2NameTagValue(rtb.Tags),
+			RouteTableName:   n.name(rtb.Tags, rtb.RouteTableId),
 			Region:           region,
 		}
-		for _, rt := range rtb.Routes {
-			destinationType, destination, err := getEc2RouteDestination(rt)
-			if err != nil {
-				return err
-			}
-			targetType, target, err := getEc2RouteTarget(rt)
-			if err != nil {
-				return err
-			}
-			obj.DestinationType = destinationType
-			obj.Destination = destination
-			obj.TargetType = targetType
-			obj.Target = target
+		routes, err := handleRoutes(*rtb, vpcs, gws, ples, region, n)
+		if err != nil {
+			return err
+		}
+		for _, route := range routes {
+			obj.DestinationType = route.DestinationType
+			obj.Destination = route.Destination
+			obj.TargetType = route.TargetType
+			obj.Target = route.Target
+			obj.TargetName = route.TargetName
+			obj.TargetState = route.TargetState
+			obj.PrefixList = route.PrefixList
 			ich <- obj
 		}
 	}
 	return nil
 }
+
+type SubnetNetworkAclInfo struct {
+	SubnetId         string
+	SubnetName       string
+	AvailabilityZone string
+	VpcId            string
+	VpcName          string
+	NetworkAclId     string
+	NetworkAclName   string
+	FlowDirection    string
+	RuleNumber       int32
+	RuleAction       types.RuleAction
+	IpProtocol       string
+	FromPort         int32
+	ToPort           int32
+	AddressType      string
+	CidrBlock        string
+	Region           string
+}
+
+func FetchDataForSubnetNetworkAclInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (map[string]types.Vpc, map[string]types.NetworkAcl, error) {
+	vpcs := make(map[string]types.Vpc)
+	acls := make(map[string]types.NetworkAcl)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	eg.Go(func() error {
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		acls, err = client.FetchNetworkAcls(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return vpcs, acls, nil
+}
+
+func GetSubnetNetworkAclInfo(ich chan<- SubnetNetworkAclInfo, subnets []types.Subnet, region string, vpcs map[string]types.Vpc, acls map[string]types.NetworkAcl, n *Namer) error {
+	for _, subnet := range subnets {
+		acl, err := findEc2NetworkAclBySubnet(subnet, acls)
+		if err != nil {
+			return err
+		}
+		entries, err := handleNetworkAclEntries(*acl, vpcs, region, n)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			ich <- SubnetNetworkAclInfo{
+				SubnetId:         aws.ToString(subnet.SubnetId),
+				SubnetName:       n.name(subnet.Tags, subnet.SubnetId),
+				AvailabilityZone: aws.ToString(subnet.AvailabilityZone),
+				VpcId:            entry.VpcId,
+				VpcName:          entry.VpcName,
+				NetworkAclId:     entry.NetworkAclId,
+				NetworkAclName:   entry.NetworkAclName,
+				FlowDirection:    entry.FlowDirection,
+				RuleNumber:       entry.RuleNumber,
+				RuleAction:       entry.RuleAction,
+				IpProtocol:       entry.IpProtocol,
+				FromPort:         entry.FromPort,
+				ToPort:           entry.ToPort,
+				AddressType:      entry.AddressType,
+				CidrBlock:        entry.CidrBlock,
+				Region:           region,
+			}
+		}
+	}
+	return nil
+}
+
+// reservedIpAddressCount is the number of addresses AWS reserves in every subnet CIDR block:
+// the network address, the VPC router, the DNS server, one for future use and the broadcast address.
+const reservedIpAddressCount = 5
+
+// getEc2SubnetIpAddressCount returns the usable addresses of an IPv4 subnet CIDR block, or 0 for IPv6 only subnets.
+func getEc2SubnetIpAddressCount(cidr string) int32 {
+	n := getEc2CidrIpAddressCount(cidr)
+	if n <= reservedIpAddressCount {
+		return 0
+	}
+	return n - reservedIpAddressCount
+}
+
+// getEc2CidrIpAddressCount returns the addresses of an IPv4 CIDR block, or 0 for anything else.
+func getEc2CidrIpAddressCount(cidr string) int32 {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil || !prefix.Addr().Is4() || prefix.Bits() < 2 {
+		return 0
+	}
+	return 1 << (32 - prefix.Bits())
+}
+
+// getEc2Utilization returns the percentage of used in total, rounded to one decimal place.
+func getEc2Utilization(used, total int32) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(used)*1000/float64(total)) / 10
+}
@@ -0,0 +1,366 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeTransitGatewaysInput(ids, names []string, filters []types.Filter, _ bool) *ec2.DescribeTransitGatewaysInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("transit-gateway-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	return &ec2.DescribeTransitGatewaysInput{
+		Filters: f,
+	}
+}
+
+var TransitGatewayLister = &Lister[types.TransitGateway]{
+	Name:    "DescribeTransitGateways",
+	Filters: transitGatewayFilterNames,
+	List:    listTransitGateways,
+	Named: namedBy(func(item types.TransitGateway) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listTransitGateways(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.TransitGateway, *string, error) {
+	input := CreateDescribeTransitGatewaysInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeTransitGateways(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.TransitGateways, o.NextToken, nil
+}
+
+// TransitGatewayResources holds the resources of a region that transit gateway attachments refer to.
+type TransitGatewayResources struct {
+	TransitGateways map[string]types.TransitGateway
+	Attachments     map[string]types.TransitGatewayAttachment
+	RouteTables     map[string]types.TransitGatewayRouteTable
+	Vpcs            map[string]types.Vpc
+	VpnConnections  map[string]types.VpnConnection
+}
+
+func FetchTransitGatewayResources(ctx context.Context, l *rate.Limiter, client IEc2Client, region string) (*TransitGatewayResources, error) {
+	r := &TransitGatewayResources{}
+	eg, ctx := errgroup.WithContext(ctx)
+	fetch := func(f func(ctx context.Context) error) {
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			return f(ctx)
+		})
+	}
+	fetch(func(ctx context.Context) (err error) {
+		r.TransitGateways, err = client.FetchTransitGateways(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		r.Attachments, err = client.FetchTransitGatewayAttachments(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		r.RouteTables, err = client.FetchTransitGatewayRouteTables(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		r.Vpcs, err = client.FetchVpcs(ctx, region)
+		return err
+	})
+	fetch(func(ctx context.Context) (err error) {
+		r.VpnConnections, err = client.FetchVpnConnections(ctx, region)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// attachmentName returns the name of the attachment, or the id if it is not found.
+func (r *TransitGatewayResources) attachmentName(id *string, n *Namer) string {
+	if attach, ok := r.Attachments[aws.ToString(id)]; ok {
+		return n.name(attach.Tags, attach.TransitGatewayAttachmentId)
+	}
+	return aws.ToString(id)
+}
+
+// resourceName returns the name of the VPC, VPN connection or peer transit gateway an attachment refers to.
+// Resources in other accounts or regions and other resource types are shown by id.
+func (r *TransitGatewayResources) resourceName(resourceType types.TransitGatewayAttachmentResourceType, id *string, n *Namer) string {
+	key := aws.ToString(id)
+	switch resourceType {
+	case types.TransitGatewayAttachmentResourceTypeVpc:
+		if vpc, ok := r.Vpcs[key]; ok {
+			return n.name(vpc.Tags, vpc.VpcId)
+		}
+	case types.TransitGatewayAttachmentResourceTypeVpn:
+		if vpn, ok := r.VpnConnections[key]; ok {
+			return n.name(vpn.Tags, vpn.VpnConnectionId)
+		}
+	case types.TransitGatewayAttachmentResourceTypePeering:
+		if tgw, ok := r.TransitGateways[key]; ok {
+			return n.name(tgw.Tags, tgw.TransitGatewayId)
+		}
+	}
+	return key
+}
+
+type TransitGatewayInfo struct {
+	TransitGatewayId             string
+	TransitGatewayName           string
+	OwnerId                      string
+	State                        types.TransitGatewayState
+	AmazonSideAsn                int64
+	DefaultRouteTableAssociation types.DefaultRouteTableAssociationValue
+	DefaultRouteTablePropagation types.DefaultRouteTablePropagationValue
+	AssociationRouteTableId      string
+	PropagationRouteTableId      string
+	DnsSupport                   types.DnsSupportValue
+	VpnEcmpSupport               types.VpnEcmpSupportValue
+	Region                       string
+}
+
+func GetTransitGatewayInfo(ich chan<- TransitGatewayInfo, tgws []types.TransitGateway, region string, n *Namer) {
+	for _, tgw := range tgws {
+		obj := TransitGatewayInfo{
+			TransitGatewayId:   aws.ToString(tgw.TransitGatewayId),
+			TransitGatewayName: n.name(tgw.Tags, tgw.TransitGatewayId),
+			OwnerId:            aws.ToString(tgw.OwnerId),
+			State:              tgw.State,
+			Region:             region,
+		}
+		if o := tgw.Options; o != nil {
+			obj.AmazonSideAsn = aws.ToInt64(o.AmazonSideAsn)
+			obj.DefaultRouteTableAssociation = o.DefaultRouteTableAssociation
+			obj.DefaultRouteTablePropagation = o.DefaultRouteTablePropagation
+			obj.AssociationRouteTableId = aws.ToString(o.AssociationDefaultRouteTableId)
+			obj.PropagationRouteTableId = aws.ToString(o.PropagationDefaultRouteTableId)
+			obj.DnsSupport = o.DnsSupport
+			obj.VpnEcmpSupport = o.VpnEcmpSupport
+		}
+		ich <- obj
+	}
+}
+
+type TransitGatewayAttachmentInfo struct {
+	TransitGatewayId         string
+	TransitGatewayName       string
+	AttachmentId             string
+	AttachmentName           string
+	ResourceType             types.TransitGatewayAttachmentResourceType
+	ResourceId               string
+	ResourceName             string
+	ResourceOwnerId          string
+	State                    types.TransitGatewayAttachmentState
+	AssociatedRouteTableId   string
+	AssociatedRouteTableName string
+	Region                   string
+}
+
+// GetTransitGatewayAttachmentInfo sends the transit gateways with their VPC, VPN, peering and other attachments,
+// and the route table each attachment is associated with.
+func GetTransitGatewayAttachmentInfo(ich chan<- TransitGatewayAttachmentInfo, tgws []types.TransitGateway, region string, res *TransitGatewayResources, n *Namer) {
+	for _, tgw := range tgws {
+		tgwId := aws.ToString(tgw.TransitGatewayId)
+		obj := TransitGatewayAttachmentInfo{
+			TransitGatewayId:   tgwId,
+			TransitGatewayName: n.name(tgw.Tags, tgw.TransitGatewayId),
+			Region:             region,
+		}
+		for _, attach := range res.Attachments {
+			if aws.ToString(attach.TransitGatewayId) != tgwId || attach.State == types.TransitGatewayAttachmentStateDeleted {
+				continue
+			}
+			row := obj
+			row.AttachmentId = aws.ToString(attach.TransitGatewayAttachmentId)
+			row.AttachmentName = n.name(attach.Tags, attach.TransitGatewayAttachmentId)
+			row.ResourceType = attach.ResourceType
+			row.ResourceId = aws.ToString(attach.ResourceId)
+			row.ResourceName = res.resourceName(attach.ResourceType, attach.ResourceId, n)
+			row.ResourceOwnerId = aws.ToString(attach.ResourceOwnerId)
+			row.State = attach.State
+			if attach.Association != nil {
+				row.AssociatedRouteTableId = aws.ToString(attach.Association.TransitGatewayRouteTableId)
+				if rtb, ok := res.RouteTables[row.AssociatedRouteTableId]; ok {
+					row.AssociatedRouteTableName = n.name(rtb.Tags, rtb.TransitGatewayRouteTableId)
+				}
+			}
+			ich <- row
+		}
+	}
+}
+
+type TransitGatewayRouteTableInfo struct {
+	TransitGatewayId   string
+	TransitGatewayName string
+	RouteTableId       string
+	RouteTableName     string
+	DefaultAssociation bool
+	DefaultPropagation bool
+	BindingType        string
+	AttachmentId       string
+	AttachmentName     string
+	ResourceType       types.TransitGatewayAttachmentResourceType
+	ResourceId         string
+	ResourceName       string
+	State              string
+	Region             string
+}
+
+// GetTransitGatewayRouteTableInfo sends the route tables of the transit gateways with the attachments
+// associated with them and the attachments propagating routes to them.
+func GetTransitGatewayRouteTableInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, ich chan<- TransitGatewayRouteTableInfo, tgws []types.TransitGateway, region string, res *TransitGatewayResources, n *Namer) error {
+	for _, tgw := range tgws {
+		tgwId := aws.ToString(tgw.TransitGatewayId)
+		for _, rtb := range res.RouteTables {
+			if aws.ToString(rtb.TransitGatewayId) != tgwId || rtb.State == types.TransitGatewayRouteTableStateDeleted {
+				continue
+			}
+			var assocs []types.TransitGatewayRouteTableAssociation
+			var props []types.TransitGatewayRouteTablePropagation
+			eg, ctx := errgroup.WithContext(ctx)
+			eg.Go(func() error {
+				if err := l.Wait(ctx); err != nil {
+					return err
+				}
+				var err error
+				assocs, err = client.GetTransitGatewayRouteTableAssociations(ctx, region, rtb.TransitGatewayRouteTableId)
+				return err
+			})
+			eg.Go(func() error {
+				if err := l.Wait(ctx); err != nil {
+					return err
+				}
+				var err error
+				props, err = client.GetTransitGatewayRouteTablePropagations(ctx, region, rtb.TransitGatewayRouteTableId)
+				return err
+			})
+			if err := eg.Wait(); err != nil {
+				return err
+			}
+			obj := TransitGatewayRouteTableInfo{
+				TransitGatewayId:   tgwId,
+				TransitGatewayName: n.name(tgw.Tags, tgw.TransitGatewayId),
+				RouteTableId:       aws.ToString(rtb.TransitGatewayRouteTableId),
+				RouteTableName:     n.name(rtb.Tags, rtb.TransitGatewayRouteTableId),
+				DefaultAssociation: aws.ToBool(rtb.DefaultAssociationRouteTable),
+				DefaultPropagation: aws.ToBool(rtb.DefaultPropagationRouteTable),
+				Region:             region,
+			}
+			if len(assocs) == 0 && len(props) == 0 {
+				ich <- obj
+				continue
+			}
+			for _, assoc := range assocs {
+				row := obj
+				row.BindingType = bindingTypeAssociation.String()
+				row.AttachmentId = aws.ToString(assoc.TransitGatewayAttachmentId)
+				row.AttachmentName = res.attachmentName(assoc.TransitGatewayAttachmentId, n)
+				row.ResourceType = assoc.ResourceType
+				row.ResourceId = aws.ToString(assoc.ResourceId)
+				row.ResourceName = res.resourceName(assoc.ResourceType, assoc.ResourceId, n)
+				row.State = string(assoc.State)
+				ich <- row
+			}
+			for _, prop := range props {
+				row := obj
+				row.BindingType = bindingTypePropagation.String()
+				row.AttachmentId = aws.ToString(prop.TransitGatewayAttachmentId)
+				row.AttachmentName = res.attachmentName(prop.TransitGatewayAttachmentId, n)
+				row.ResourceType = prop.ResourceType
+				row.ResourceId = aws.ToString(prop.ResourceId)
+				row.ResourceName = res.resourceName(prop.ResourceType, prop.ResourceId, n)
+				row.State = string(prop.State)
+				ich <- row
+			}
+		}
+	}
+	return nil
+}
+
+type TransitGatewayRouteInfo struct {
+	TransitGatewayId   string
+	TransitGatewayName string
+	RouteTableId       string
+	RouteTableName     string
+	DestinationType    string
+	Destination        string
+	RouteType          types.TransitGatewayRouteType
+	State              types.TransitGatewayRouteState
+	AttachmentId       string
+	AttachmentName     string
+	ResourceType       types.TransitGatewayAttachmentResourceType
+	ResourceId         string
+	ResourceName       string
+	Region             string
+}
+
+// GetTransitGatewayRouteInfo sends the static and propagated routes of the route tables of the transit gateways.
+// A route load balanced over several attachments is sent once per attachment, a blackhole route without any.
+func GetTransitGatewayRouteInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, ich chan<- TransitGatewayRouteInfo, tgws []types.TransitGateway, region string, res *TransitGatewayResources, n *Namer) error {
+	for _, tgw := range tgws {
+		tgwId := aws.ToString(tgw.TransitGatewayId)
+		for _, rtb := range res.RouteTables {
+			if aws.ToString(rtb.TransitGatewayId) != tgwId || rtb.State == types.TransitGatewayRouteTableStateDeleted {
+				continue
+			}
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			routes, err := client.SearchTransitGatewayRoutes(ctx, region, rtb.TransitGatewayRouteTableId)
+			if err != nil {
+				return err
+			}
+			obj := TransitGatewayRouteInfo{
+				TransitGatewayId:   tgwId,
+				TransitGatewayName: n.name(tgw.Tags, tgw.TransitGatewayId),
+				RouteTableId:       aws.ToString(rtb.TransitGatewayRouteTableId),
+				RouteTableName:     n.name(rtb.Tags, rtb.TransitGatewayRouteTableId),
+				Region:             region,
+			}
+			for _, rt := range routes {
+				row := obj
+				row.DestinationType, row.Destination = getEc2TransitGatewayRouteDestination(rt)
+				row.RouteType = rt.Type
+				row.State = rt.State
+				if len(rt.TransitGatewayAttachments) == 0 {
+					ich <- row
+					continue
+				}
+				for _, attach := range rt.TransitGatewayAttachments {
+					row.AttachmentId = aws.ToString(attach.TransitGatewayAttachmentId)
+					row.AttachmentName = res.attachmentName(attach.TransitGatewayAttachmentId, n)
+					row.ResourceType = attach.ResourceType
+					row.ResourceId = aws.ToString(attach.ResourceId)
+					row.ResourceName = res.resourceName(attach.ResourceType, attach.ResourceId, n)
+					ich <- row
+				}
+			}
+		}
+	}
+	return nil
+}
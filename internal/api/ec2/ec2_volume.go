@@ -0,0 +1,145 @@
+package ec2
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/time/rate"
+)
+
+func CreateDescribeVolumesInput(ids, names []string, filters []types.Filter, _ bool) *ec2.DescribeVolumesInput {
+	var f []types.Filter
+	if len(ids) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("volume-id"),
+			Values: ids,
+		})
+	}
+	if len(names) > 0 {
+		f = append(f, types.Filter{
+			Name:   aws.String("tag:Name"),
+			Values: names,
+		})
+	}
+	if len(filters) > 0 {
+		f = append(f, filters...)
+	}
+	return &ec2.DescribeVolumesInput{
+		Filters: f,
+	}
+}
+
+var VolumeLister = &Lister[types.Volume]{
+	Name:    "DescribeVolumes",
+	Filters: volumeFilterNames,
+	List:    listVolumes,
+	Named: namedBy(func(item types.Volume) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listVolumes(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Volume, *string, error) {
+	input := CreateDescribeVolumesInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	if in.Unattached {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("status"),
+			Values: []string{string(types.VolumeStateAvailable)},
+		})
+	}
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeVolumes(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.Volumes, o.NextToken, nil
+}
+
+type VolumeInfo struct {
+	VolumeId         string
+	VolumeName       string
+	State            types.VolumeState
+	VolumeType       types.VolumeType
+	VolumeSize       int32
+	IOPS             int32
+	Throughput       int32
+	Encrypted        bool
+	KmsKeyId         string
+	InstanceId       string
+	InstanceName     string
+	DeviceName       string
+	AvailabilityZone string
+	Region           string
+}
+
+func GetVolumeInfo(ich chan<- VolumeInfo, vols []types.Volume, region string, instances map[string]types.Instance, n *Namer) {
+	for _, vol := range vols {
+		obj := VolumeInfo{
+			VolumeId:         aws.ToString(vol.VolumeId),
+			VolumeName:       n.name(vol.Tags, vol.VolumeId),
+			State:            vol.State,
+			VolumeType:       vol.VolumeType,
+			VolumeSize:       aws.ToInt32(vol.Size),
+			IOPS:             aws.ToInt32(vol.Iops),
+			Throughput:       aws.ToInt32(vol.Throughput),
+			Encrypted:        aws.ToBool(vol.Encrypted),
+			KmsKeyId:         aws.ToString(vol.KmsKeyId),
+			AvailabilityZone: aws.ToString(vol.AvailabilityZone),
+			Region:           region,
+		}
+		if len(vol.Attachments) == 0 {
+			ich <- obj
+			continue
+		}
+		// Multi-attach volumes have a row per instance.
+		for _, a := range vol.Attachments {
+			row := obj
+			row.InstanceId = aws.ToString(a.InstanceId)
+			row.DeviceName = aws.ToString(a.Device)
+			if i, ok := instances[row.InstanceId]; ok {
+				row.InstanceName = n.name(i.Tags, i.PrivateDnsName, i.InstanceId)
+			}
+			ich <- row
+		}
+	}
+}
+
+type VolumeSnapshotInfo struct {
+	VolumeId          string
+	VolumeName        string
+	State             types.VolumeState
+	VolumeSize        int32
+	SnapshotId        string
+	SnapshotName      string
+	SnapshotStartTime string
+	SnapshotAgeDays   int
+	AvailabilityZone  string
+	Region            string
+}
+
+// GetVolumeSnapshotInfo sends the volumes with the snapshots they were created from.
+// Snapshots not owned by the account, such as public ones, are shown by id only.
+func GetVolumeSnapshotInfo(ich chan<- VolumeSnapshotInfo, vols []types.Volume, region string, snps map[string]types.Snapshot, now time.Time, n *Namer) {
+	for _, vol := range vols {
+		obj := VolumeSnapshotInfo{
+			VolumeId:         aws.ToString(vol.VolumeId),
+			VolumeName:       n.name(vol.Tags, vol.VolumeId),
+			State:            vol.State,
+			VolumeSize:       aws.ToInt32(vol.Size),
+			SnapshotId:       aws.ToString(vol.SnapshotId),
+			AvailabilityZone: aws.ToString(vol.AvailabilityZone),
+			Region:           region,
+		}
+		if snp := findEc2SnapshotById(obj.SnapshotId, snps); snp != nil {
+			obj.SnapshotName = n.name(snp.Tags, snp.SnapshotId)
+			obj.SnapshotStartTime = formatTime(snp.StartTime)
+			obj.SnapshotAgeDays = ageDays(snp.StartTime, now)
+		}
+		ich <- obj
+	}
+}
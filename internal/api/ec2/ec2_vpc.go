@@ -1,7 +1,11 @@
 package ec2
 
 import (
+	"cmp"
 	"context"
+	"net/netip"
+	"slices"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -38,6 +42,99 @@ func CreateDescribeVpcsInput(ids, names []string, filters []types.Filter, defaul
 	}
 }
 
+var VpcLister = &Lister[types.Vpc]{
+	Name:    "DescribeVpcs",
+	Filters: vpcFilterNames,
+	List:    listVpcs,
+	Named: namedBy(func(item types.Vpc) []types.Tag {
+		return item.Tags
+	}),
+}
+
+func listVpcs(ctx context.Context, _ *rate.Limiter, client IEc2Client, region string, in *Input, token *string) ([]types.Vpc, *string, error) {
+	input := CreateDescribeVpcsInput(in.Ids, in.Names, in.Filters, in.DefaultFilter)
+	input.NextToken = token
+	opt := func(opt *ec2.Options) {
+		opt.Region = region
+	}
+	o, err := client.DescribeVpcs(ctx, input, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.Vpcs, o.NextToken, nil
+}
+
+// SelectVpcIds returns the ids of the VPCs matching the ids, names and filters of the input as DescribeVpcs matches them,
+// so that VPCs listed without a selection can be narrowed without listing them again.
+func SelectVpcIds(vpcs []types.Vpc, in *Input) []string {
+	var ids []string
+	for _, vpc := range vpcs {
+		id := aws.ToString(vpc.VpcId)
+		if len(in.Ids) > 0 && !slices.Contains(in.Ids, id) {
+			continue
+		}
+		if len(in.Names) > 0 && !slices.Contains(in.Names, in.Namer.tagName(vpc.Tags)) {
+			continue
+		}
+		if !matchEc2Filters(in.Filters, func(name string) []string {
+			return vpcFilterValues(vpc, name)
+		}) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// vpcFilterValues returns the values of the VPC matched by the DescribeVpcs filter.
+func vpcFilterValues(vpc types.Vpc, name string) []string {
+	if res, ok := tagFilterValues(vpc.Tags, name); ok {
+		return res
+	}
+	var res []string
+	switch name {
+	case "cidr":
+		res = append(res, aws.ToString(vpc.CidrBlock))
+	case "dhcp-options-id":
+		res = append(res, aws.ToString(vpc.DhcpOptionsId))
+	case "is-default":
+		res = append(res, strconv.FormatBool(aws.ToBool(vpc.IsDefault)))
+	case "owner-id":
+		res = append(res, aws.ToString(vpc.OwnerId))
+	case "state":
+		res = append(res, string(vpc.State))
+	case "vpc-id":
+		res = append(res, aws.ToString(vpc.VpcId))
+	}
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		switch name {
+		case "cidr-block-association.association-id":
+			res = append(res, aws.ToString(assoc.AssociationId))
+		case "cidr-block-association.cidr-block":
+			res = append(res, aws.ToString(assoc.CidrBlock))
+		case "cidr-block-association.state":
+			if assoc.CidrBlockState != nil {
+				res = append(res, string(assoc.CidrBlockState.State))
+			}
+		}
+	}
+	for _, assoc := range vpc.Ipv6CidrBlockAssociationSet {
+		switch name {
+		case "ipv6-cidr-block-association.association-id":
+			res = append(res, aws.ToString(assoc.AssociationId))
+		case "ipv6-cidr-block-association.ipv6-cidr-block":
+			res = append(res, aws.ToString(assoc.Ipv6CidrBlock))
+		case "ipv6-cidr-block-association.ipv6-pool":
+			res = append(res, aws.ToString(assoc.Ipv6Pool))
+		case "ipv6-cidr-block-association.state":
+			if assoc.Ipv6CidrBlockState != nil {
+				res = append(res, string(assoc.Ipv6CidrBlockState.State))
+			}
+		}
+	}
+	return res
+}
+
 type VpcInfo struct {
 	VpcId           string
 	VpcName         string
@@ -49,7 +146,7 @@ type VpcInfo struct {
 	Region          string
 }
 
-func GetVpcInfo(ich chan<- VpcInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions) error {
+func GetVpcInfo(ich chan<- VpcInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions, n *Namer) error {
 	for _, vpc := range vpcs {
 		dhcpOptId := aws.ToString(vpc.DhcpOptionsId)
 		dhcpOpt, err := findEc2DhcpOptionById(dhcpOptId, dopts)
@@ -58,9 +155,9 @@ func GetVpcInfo(ich chan<- VpcInfo, vpcs []types.Vpc, region string, dopts map[s
 		}
 		ich <- VpcInfo{
 			VpcId:           aws.ToString(vpc.VpcId),
-			VpcName:         getEc2NameTagValue(vpc.Tags),
+			VpcName:         n.name(vpc.Tags, vpc.VpcId),
 			DhcpOptionsId:   dhcpOptId,
-			DhcpOptionsName: getEc2NameTagValue(dhcpOpt.Tags),
+			DhcpOptionsName: n.name(dhcpOpt.Tags, dhcpOpt.DhcpOptionsId),
 			IsDefault:       aws.ToBool(vpc.IsDefault),
 			InstanceTenancy: vpc.InstanceTenancy,
 			OwnerId:         aws.ToString(vpc.OwnerId),
@@ -83,7 +180,7 @@ type VpcAttributeInfo struct {
 	Region             string
 }
 
-func GetVpcAttributeInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, ich chan<- VpcAttributeInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions) error {
+func GetVpcAttributeInfo(ctx context.Context, l *rate.Limiter, client IEc2Client, ich chan<- VpcAttributeInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions, n *Namer) error {
 	for _, vpc := range vpcs {
 		var enableDnsSupport, enableDnsHostnames bool
 		eg, ctx := errgroup.WithContext(ctx)
@@ -113,11 +210,11 @@ func GetVpcAttributeInfo(ctx context.Context, l *rate.Limiter, client IEc2Client
 		}
 		ich <- VpcAttributeInfo{
 			VpcId:              aws.ToString(vpc.VpcId),
-			VpcName:            getEc2NameTagValue(vpc.Tags),
+			VpcName:            n.name(vpc.Tags, vpc.VpcId),
 			EnableDnsSupport:   enableDnsSupport,
 			EnableDnsHostnames: enableDnsHostnames,
 			DhcpOptionsId:      doptId,
-			DhcpOptionsName:    getEc2NameTagValue(dopt.Tags),
+			DhcpOptionsName:    n.name(dopt.Tags, dopt.DhcpOptionsId),
 			IsDefault:          aws.ToBool(vpc.IsDefault),
 			InstanceTenancy:    vpc.InstanceTenancy,
 			OwnerId:            aws.ToString(vpc.OwnerId),
@@ -138,12 +235,13 @@ type VpcCidrInfo struct {
 	State              types.VpcState
 	AddressType        string
 	CidrBlock          string
+	AssociationState   string
 	NetworkBorderGroup string
 	Pool               string
 	Region             string
 }
 
-func GetVpcCidrInfo(ich chan<- VpcCidrInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions) error {
+func GetVpcCidrInfo(ich chan<- VpcCidrInfo, vpcs []types.Vpc, region string, dopts map[string]types.DhcpOptions, n *Namer) error {
 	for _, vpc := range vpcs {
 		dhcpOptId := aws.ToString(vpc.DhcpOptionsId)
 		dhcpOpt, err := findEc2DhcpOptionById(dhcpOptId, dopts)
@@ -152,9 +250,9 @@ func GetVpcCidrInfo(ich chan<- VpcCidrInfo, vpcs []types.Vpc, region string, dop
 		}
 		obj := VpcCidrInfo{
 			VpcId:           aws.ToString(vpc.VpcId),
-			VpcName:         getEc2NameTagValue(vpc.Tags),
+			VpcName:         n.name(vpc.Tags, vpc.VpcId),
 			DhcpOptionsId:   dhcpOptId,
-			DhcpOptionsName: getEc2NameTagValue(dhcpOpt.Tags),
+			DhcpOptionsName: n.name(dhcpOpt.Tags, dhcpOpt.DhcpOptionsId),
 			IsDefault:       aws.ToBool(vpc.IsDefault),
 			InstanceTenancy: vpc.InstanceTenancy,
 			OwnerId:         aws.ToString(vpc.OwnerId),
@@ -164,11 +262,19 @@ func GetVpcCidrInfo(ich chan<- VpcCidrInfo, vpcs []types.Vpc, region string, dop
 		for _, assoc := range vpc.CidrBlockAssociationSet {
 			obj.AddressType = addressTypeIpv4.String()
 			obj.CidrBlock = aws.ToString(assoc.CidrBlock)
+			obj.AssociationState = ""
+			if assoc.CidrBlockState != nil {
+				obj.AssociationState = string(assoc.CidrBlockState.State)
+			}
 			ich <- obj
 		}
 		for _, assoc := range vpc.Ipv6CidrBlockAssociationSet {
 			obj.AddressType = addressTypeIpv6.String()
 			obj.CidrBlock = aws.ToString(assoc.Ipv6CidrBlock)
+			obj.AssociationState = ""
+			if assoc.Ipv6CidrBlockState != nil {
+				obj.AssociationState = string(assoc.Ipv6CidrBlockState.State)
+			}
 			obj.NetworkBorderGroup = aws.ToString(assoc.NetworkBorderGroup)
 			obj.Pool = aws.ToString(assoc.Ipv6Pool)
 			ich <- obj
@@ -176,3 +282,139 @@ func GetVpcCidrInfo(ich chan<- VpcCidrInfo, vpcs []types.Vpc, region string, dop
 	}
 	return nil
 }
+
+type VpcCidrOverlapInfo struct {
+	VpcId            string
+	VpcName          string
+	OwnerId          string
+	AddressType      string
+	CidrBlock        string
+	Overlap          string
+	PeerVpcId        string
+	PeerVpcName      string
+	PeerOwnerId      string
+	PeerRegion       string
+	PeerCidrBlock    string
+	OverlapCidrBlock string
+	Region           string
+}
+
+// GetVpcCidrOverlapInfo returns a row per CIDR block of a selected VPC overlapping a block of another VPC,
+// whatever the region or the owner of the VPCs, so that a pair of selected VPCs is returned from both sides.
+// All VPCs are selected when vpcIds is empty. Overlap tells whether the block is equal to, contains or is within
+// the block of the peer, and OverlapCidrBlock is the addresses they share. Blocks not in the associated state are ignored.
+func GetVpcCidrOverlapInfo(cidrs []VpcCidrInfo, vpcIds []string) []VpcCidrOverlapInfo {
+	type block struct {
+		VpcCidrInfo
+		prefix netip.Prefix
+	}
+	var blocks []block
+	for _, cidr := range cidrs {
+		if cidr.AssociationState != "" && cidr.AssociationState != string(types.VpcCidrBlockStateCodeAssociated) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr.CidrBlock)
+		if err != nil {
+			continue
+		}
+		blocks = append(blocks, block{cidr, prefix.Masked()})
+	}
+	slices.SortFunc(blocks, func(a, b block) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcId, b.VpcId),
+			a.prefix.Addr().Compare(b.prefix.Addr()),
+			cmp.Compare(a.prefix.Bits(), b.prefix.Bits()),
+		)
+	})
+	var info []VpcCidrOverlapInfo
+	for _, a := range blocks {
+		if len(vpcIds) > 0 && !slices.Contains(vpcIds, a.VpcId) {
+			continue
+		}
+		for _, b := range blocks {
+			if a.VpcId == b.VpcId && a.Region == b.Region || !a.prefix.Overlaps(b.prefix) {
+				continue
+			}
+			obj := VpcCidrOverlapInfo{
+				VpcId:         a.VpcId,
+				VpcName:       a.VpcName,
+				OwnerId:       a.OwnerId,
+				AddressType:   a.AddressType,
+				CidrBlock:     a.CidrBlock,
+				PeerVpcId:     b.VpcId,
+				PeerVpcName:   b.VpcName,
+				PeerOwnerId:   b.OwnerId,
+				PeerRegion:    b.Region,
+				PeerCidrBlock: b.CidrBlock,
+				Region:        a.Region,
+			}
+			switch {
+			case a.prefix.Bits() == b.prefix.Bits():
+				obj.Overlap = cidrOverlapEqual.String()
+				obj.OverlapCidrBlock = a.prefix.String()
+			case a.prefix.Bits() < b.prefix.Bits():
+				obj.Overlap = cidrOverlapContains.String()
+				obj.OverlapCidrBlock = b.prefix.String()
+			default:
+				obj.Overlap = cidrOverlapWithin.String()
+				obj.OverlapCidrBlock = a.prefix.String()
+			}
+			info = append(info, obj)
+		}
+	}
+	return info
+}
+
+type VpcCapacityInfo struct {
+	VpcId                     string
+	VpcName                   string
+	OwnerId                   string
+	CidrIpAddressCount        int32
+	AllocatedIpAddressCount   int32
+	UnallocatedIpAddressCount int32
+	SubnetCount               int
+	AvailableIpAddressCount   int32
+	UsedIpAddressCount        int32
+	TotalIpAddressCount       int32
+	Utilization               float64
+	Region                    string
+}
+
+// GetVpcCapacityInfo sends the VPCs with the IPv4 capacity rolled up from their subnets.
+// CidrIpAddressCount counts the addresses of the associated CIDR blocks, of which the subnets take AllocatedIpAddressCount.
+// The other counts and Utilization are the usable addresses of the subnets as in SubnetInfo.
+// VPCs used less than minUtilization percent are skipped.
+func GetVpcCapacityInfo(ich chan<- VpcCapacityInfo, vpcs []types.Vpc, region string, sbns map[string]types.Subnet, minUtilization float64, n *Namer) {
+	for _, vpc := range vpcs {
+		vpcId := aws.ToString(vpc.VpcId)
+		obj := VpcCapacityInfo{
+			VpcId:   vpcId,
+			VpcName: n.name(vpc.Tags, vpc.VpcId),
+			OwnerId: aws.ToString(vpc.OwnerId),
+			Region:  region,
+		}
+		for _, assoc := range vpc.CidrBlockAssociationSet {
+			if assoc.CidrBlockState != nil && assoc.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+			obj.CidrIpAddressCount += getEc2CidrIpAddressCount(aws.ToString(assoc.CidrBlock))
+		}
+		for _, sbn := range sbns {
+			if aws.ToString(sbn.VpcId) != vpcId {
+				continue
+			}
+			obj.SubnetCount++
+			obj.AllocatedIpAddressCount += getEc2CidrIpAddressCount(aws.ToString(sbn.CidrBlock))
+			obj.TotalIpAddressCount += getEc2SubnetIpAddressCount(aws.ToString(sbn.CidrBlock))
+			obj.AvailableIpAddressCount += aws.ToInt32(sbn.AvailableIpAddressCount)
+		}
+		obj.UnallocatedIpAddressCount = obj.CidrIpAddressCount - obj.AllocatedIpAddressCount
+		obj.UsedIpAddressCount = obj.TotalIpAddressCount - obj.AvailableIpAddressCount
+		obj.Utilization = getEc2Utilization(obj.UsedIpAddressCount, obj.TotalIpAddressCount)
+		if obj.Utilization < minUtilization {
+			continue
+		}
+		ich <- obj
+	}
+}
@@ -0,0 +1,129 @@
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestGetVpcCidrOverlapInfo(t *testing.T) {
+	cidrs := []VpcCidrInfo{
+		{VpcId: "vpc-a", CidrBlock: "10.0.0.0/8", AssociationState: "associated", Region: "ap-northeast-1"},
+		{VpcId: "vpc-b", CidrBlock: "10.0.0.0/16", AssociationState: "associated", Region: "ap-northeast-1"},
+		{VpcId: "vpc-b", CidrBlock: "172.16.0.0/16", AssociationState: "associated", Region: "ap-northeast-1"},
+		{VpcId: "vpc-c", CidrBlock: "192.168.0.0/24", AssociationState: "associated", Region: "ap-northeast-1"},
+		{VpcId: "vpc-c", CidrBlock: "172.17.0.0/16", Region: "ap-northeast-1"},
+		{VpcId: "vpc-d", CidrBlock: "10.0.0.0/16", AssociationState: "associated", Region: "us-east-1"},
+		{VpcId: "vpc-d", CidrBlock: "192.168.0.0/16", AssociationState: "disassociated", Region: "us-east-1"},
+		{VpcId: "vpc-e", CidrBlock: "172.16.255.255/16", AssociationState: "associated", Region: "us-east-1"},
+	}
+	type row struct {
+		VpcId, CidrBlock, Overlap, PeerVpcId, OverlapCidrBlock string
+	}
+	tests := []struct {
+		name   string
+		vpcIds []string
+		want   []row
+	}{
+		{
+			name: "all",
+			want: []row{
+				{"vpc-a", "10.0.0.0/8", "Contains", "vpc-b", "10.0.0.0/16"},
+				{"vpc-a", "10.0.0.0/8", "Contains", "vpc-d", "10.0.0.0/16"},
+				{"vpc-b", "10.0.0.0/16", "Within", "vpc-a", "10.0.0.0/16"},
+				{"vpc-b", "10.0.0.0/16", "Equal", "vpc-d", "10.0.0.0/16"},
+				{"vpc-b", "172.16.0.0/16", "Equal", "vpc-e", "172.16.0.0/16"},
+				{"vpc-d", "10.0.0.0/16", "Within", "vpc-a", "10.0.0.0/16"},
+				{"vpc-d", "10.0.0.0/16", "Equal", "vpc-b", "10.0.0.0/16"},
+				{"vpc-e", "172.16.255.255/16", "Equal", "vpc-b", "172.16.0.0/16"},
+			},
+		},
+		{
+			name:   "selected against all",
+			vpcIds: []string{"vpc-d"},
+			want: []row{
+				{"vpc-d", "10.0.0.0/16", "Within", "vpc-a", "10.0.0.0/16"},
+				{"vpc-d", "10.0.0.0/16", "Equal", "vpc-b", "10.0.0.0/16"},
+			},
+		},
+		{
+			name:   "pair selected from both sides",
+			vpcIds: []string{"vpc-b", "vpc-e"},
+			want: []row{
+				{"vpc-b", "10.0.0.0/16", "Within", "vpc-a", "10.0.0.0/16"},
+				{"vpc-b", "10.0.0.0/16", "Equal", "vpc-d", "10.0.0.0/16"},
+				{"vpc-b", "172.16.0.0/16", "Equal", "vpc-e", "172.16.0.0/16"},
+				{"vpc-e", "172.16.255.255/16", "Equal", "vpc-b", "172.16.0.0/16"},
+			},
+		},
+		{
+			name:   "no overlap",
+			vpcIds: []string{"vpc-c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []row
+			for _, o := range GetVpcCidrOverlapInfo(cidrs, tt.vpcIds) {
+				got = append(got, row{o.VpcId, o.CidrBlock, o.Overlap, o.PeerVpcId, o.OverlapCidrBlock})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetVpcCidrOverlapInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectVpcIds(t *testing.T) {
+	vpcs := []types.Vpc{
+		{
+			VpcId:     aws.String("vpc-a"),
+			CidrBlock: aws.String("10.0.0.0/16"),
+			IsDefault: aws.Bool(false),
+			Tags:      []types.Tag{{Key: aws.String("Name"), Value: aws.String("prod-a")}, {Key: aws.String("env"), Value: aws.String("prod")}},
+		},
+		{
+			VpcId:     aws.String("vpc-b"),
+			CidrBlock: aws.String("10.1.0.0/16"),
+			IsDefault: aws.Bool(false),
+			Tags:      []types.Tag{{Key: aws.String("Name"), Value: aws.String("dev-b")}, {Key: aws.String("Alias"), Value: aws.String("alias-b")}},
+			CidrBlockAssociationSet: []types.VpcCidrBlockAssociation{
+				{CidrBlock: aws.String("10.1.0.0/16")},
+				{CidrBlock: aws.String("100.64.0.0/16")},
+			},
+		},
+		{
+			VpcId:     aws.String("vpc-c"),
+			CidrBlock: aws.String("172.31.0.0/16"),
+			IsDefault: aws.Bool(true),
+		},
+	}
+	filter := func(name string, values ...string) types.Filter {
+		return types.Filter{Name: aws.String(name), Values: values}
+	}
+	tests := []struct {
+		name string
+		in   *Input
+		want []string
+	}{
+		{name: "none", in: &Input{}, want: []string{"vpc-a", "vpc-b", "vpc-c"}},
+		{name: "ids", in: &Input{Ids: []string{"vpc-c", "vpc-x"}}, want: []string{"vpc-c"}},
+		{name: "names", in: &Input{Names: []string{"dev-b"}}, want: []string{"vpc-b"}},
+		{name: "names from name tag chain", in: &Input{Names: []string{"alias-b"}, Namer: &Namer{Tags: []string{"Alias", "Name"}}}, want: []string{"vpc-b"}},
+		{name: "wildcard", in: &Input{Filters: []types.Filter{filter("cidr", "10.*")}}, want: []string{"vpc-a", "vpc-b"}},
+		{name: "single character wildcard", in: &Input{Filters: []types.Filter{filter("tag:Name", "???-b")}}, want: []string{"vpc-b"}},
+		{name: "association", in: &Input{Filters: []types.Filter{filter("cidr-block-association.cidr-block", "100.64.0.0/16")}}, want: []string{"vpc-b"}},
+		{name: "every filter", in: &Input{Filters: []types.Filter{filter("is-default", "false"), filter("tag-key", "env")}}, want: []string{"vpc-a"}},
+		{name: "any value", in: &Input{Filters: []types.Filter{filter("tag-value", "prod", "dev-b")}}, want: []string{"vpc-a", "vpc-b"}},
+		{name: "no match", in: &Input{Filters: []types.Filter{filter("state", "pending")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectVpcIds(vpcs, tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectVpcIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,6 +27,8 @@ type IIamClient interface {
 	GetAttachedRolePolicies(ctx context.Context, name *string) ([]types.AttachedPolicy, error)
 	GetInlineRolePolicies(ctx context.Context, name *string) ([]string, error)
 	GetInlineRolePolicyDocument(ctx context.Context, name *string, policyName *string) (string, error)
+	GetUserTags(ctx context.Context, name *string) (map[string]string, error)
+	GetRoleTags(ctx context.Context, name *string) (map[string]string, error)
 	GetPolicyScope(scope string) (types.PolicyScopeType, error)
 	GetPolicyDocument(ctx context.Context, arn *string, version *string) (string, error)
 	GetCustomerPolicyDocument(ctx context.Context, arn *string, pols map[string]types.Policy) (string, error)
@@ -84,6 +86,14 @@ func (client *IamClient) GetInlineRolePolicyDocument(ctx context.Context, name *
 	return getIamInlineRolePolicyDocument(ctx, client.Client, name, policyName)
 }
 
+func (client *IamClient) GetUserTags(ctx context.Context, name *string) (map[string]string, error) {
+	return getIamUserTags(ctx, client.Client, name)
+}
+
+func (client *IamClient) GetRoleTags(ctx context.Context, name *string) (map[string]string, error) {
+	return getIamRoleTags(ctx, client.Client, name)
+}
+
 func (client *IamClient) GetPolicyScope(scope string) (types.PolicyScopeType, error) {
 	return getIamPolicyScope(scope)
 }
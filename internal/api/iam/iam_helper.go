@@ -156,6 +156,36 @@ func getIamInlineRolePolicyDocument(ctx context.Context, client *iam.Client, nam
 	return doc, nil
 }
 
+func getIamUserTags(ctx context.Context, client *iam.Client, name *string) (map[string]string, error) {
+	p := iam.NewListUserTagsPaginator(client, &iam.ListUserTagsInput{UserName: name})
+	res := make(map[string]string)
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page.Tags {
+			res[aws.ToString(t.Key)] = aws.ToString(t.Value)
+		}
+	}
+	return res, nil
+}
+
+func getIamRoleTags(ctx context.Context, client *iam.Client, name *string) (map[string]string, error) {
+	p := iam.NewListRoleTagsPaginator(client, &iam.ListRoleTagsInput{RoleName: name})
+	res := make(map[string]string)
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page.Tags {
+			res[aws.ToString(t.Key)] = aws.ToString(t.Value)
+		}
+	}
+	return res, nil
+}
+
 func getIamPolicyScope(scope string) (types.PolicyScopeType, error) {
 	switch scope {
 	case PolicyScopeTypeLocal.String():
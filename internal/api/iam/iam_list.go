@@ -0,0 +1,63 @@
+package iam
+
+import (
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Input narrows the resources requested from a list API and sets how policies are output.
+type Input struct {
+	Ids             []string
+	Names           []string
+	Scope           string
+	Document        bool
+	DocumentFilters []string
+}
+
+func (in *Input) match(id, name *string) bool {
+	if len(in.Ids) > 0 && !slices.Contains(in.Ids, aws.ToString(id)) {
+		return false
+	}
+	if len(in.Names) > 0 && !slices.Contains(in.Names, aws.ToString(name)) {
+		return false
+	}
+	return true
+}
+
+// Lister pages through a list API and calls fn for each item matching the input.
+type Lister[I any] func(ctx context.Context, client IIamClient, in *Input, fn func(item I) error) error
+
+// Builder sends the rows built from a listed item.
+type Builder[I, T any] func(ctx context.Context, l *rate.Limiter, ich chan<- T, item I) error
+
+// Handler fetches the data the rows depend on and returns their builder.
+type Handler[I, T any] func(ctx context.Context, client IIamClient, in *Input) (Builder[I, T], error)
+
+// List builds the rows of every listed item in parallel.
+func List[I, T any](ctx context.Context, client IIamClient, in *Input, list Lister[I], handle Handler[I, T]) ([]T, error) {
+	build, err := handle(ctx, client, in)
+	if err != nil {
+		return nil, err
+	}
+	return api.Collect(func(ich chan<- T) error {
+		eg, ctx := errgroup.WithContext(ctx)
+		l := rate.NewLimiter(rate.Limit(50), 1)
+		eg.Go(func() error {
+			return list(ctx, client, in, func(item I) error {
+				eg.Go(func() error {
+					if err := l.Wait(ctx); err != nil {
+						return err
+					}
+					return build(ctx, l, ich, item)
+				})
+				return nil
+			})
+		})
+		return eg.Wait()
+	})
+}
@@ -4,10 +4,34 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/nekrassov01/aws-describer/internal/api"
 )
 
+func ListPolicies(ctx context.Context, client IIamClient, in *Input, fn func(item types.Policy) error) error {
+	scope, err := client.GetPolicyScope(in.Scope)
+	if err != nil {
+		return err
+	}
+	p := iam.NewListPoliciesPaginator(client, &iam.ListPoliciesInput{Scope: scope})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Policies {
+			if !in.match(item.PolicyId, item.PolicyName) {
+				continue
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type PolicyInfo struct {
 	PolicyName                    string
 	PolicyId                      string
@@ -4,12 +4,32 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/nekrassov01/aws-describer/internal/api"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 )
 
+func ListRoles(ctx context.Context, client IIamClient, in *Input, fn func(item types.Role) error) error {
+	p := iam.NewListRolesPaginator(client, &iam.ListRolesInput{})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Roles {
+			if !in.match(item.RoleId, item.RoleName) {
+				continue
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type RoleInfo struct {
 	RoleName string
 	RoleId   string
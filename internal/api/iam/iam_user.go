@@ -4,12 +4,32 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/nekrassov01/aws-describer/internal/api"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 )
 
+func ListUsers(ctx context.Context, client IIamClient, in *Input, fn func(item types.User) error) error {
+	p := iam.NewListUsersPaginator(client, &iam.ListUsersInput{})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Users {
+			if !in.match(item.UserId, item.UserName) {
+				continue
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type UserInfo struct {
 	UserName string
 	UserId   string
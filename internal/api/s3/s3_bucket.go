@@ -2,12 +2,30 @@ package s3
 
 import (
 	"context"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/nekrassov01/aws-describer/internal/api"
 )
 
+func ListBuckets(ctx context.Context, client IS3Client, in *Input, fn func(item types.Bucket) error) error {
+	o, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return err
+	}
+	for _, item := range o.Buckets {
+		if len(in.Names) > 0 && !slices.Contains(in.Names, aws.ToString(item.Name)) {
+			continue
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type BucketInfo struct {
 	BucketName     string
 	IsAccesible    bool
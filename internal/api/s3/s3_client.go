@@ -14,6 +14,7 @@ type IS3Client interface {
 
 	GetBucketLocation(ctx context.Context, name *string) (string, bool, error)
 	GetBucketPolicyDocument(ctx context.Context, name *string, region string) (string, bool, error)
+	GetBucketTags(ctx context.Context, name *string, region string) (map[string]string, error)
 }
 
 type S3Client struct {
@@ -31,3 +32,7 @@ func (client *S3Client) GetBucketLocation(ctx context.Context, name *string) (st
 func (client *S3Client) GetBucketPolicyDocument(ctx context.Context, name *string, region string) (string, bool, error) {
 	return getS3BucketPolicyDocument(ctx, client.Client, name, region)
 }
+
+func (client *S3Client) GetBucketTags(ctx context.Context, name *string, region string) (map[string]string, error) {
+	return getS3BucketTags(ctx, client.Client, name, region)
+}
@@ -41,3 +41,22 @@ func getS3BucketPolicyDocument(ctx context.Context, client *s3.Client, name *str
 	}
 	return doc, true, nil
 }
+
+func getS3BucketTags(ctx context.Context, client *s3.Client, name *string, region string) (map[string]string, error) {
+	o, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: name,
+	}, func(opt *s3.Options) {
+		opt.Region = region
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NoSuchTagSet") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	res := make(map[string]string, len(o.TagSet))
+	for _, t := range o.TagSet {
+		res[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return res, nil
+}
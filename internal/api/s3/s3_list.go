@@ -0,0 +1,49 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Input narrows the resources requested from a list API and sets how policies are output.
+type Input struct {
+	Names           []string
+	Document        bool
+	DocumentFilters []string
+}
+
+// Lister calls the list API and calls fn for each item matching the input.
+type Lister[I any] func(ctx context.Context, client IS3Client, in *Input, fn func(item I) error) error
+
+// Builder sends the rows built from a listed item.
+type Builder[I, T any] func(ctx context.Context, ich chan<- T, item I) error
+
+// Handler fetches the data the rows depend on and returns their builder.
+type Handler[I, T any] func(ctx context.Context, client IS3Client, in *Input) (Builder[I, T], error)
+
+// List builds the rows of every listed item in parallel.
+func List[I, T any](ctx context.Context, client IS3Client, in *Input, list Lister[I], handle Handler[I, T]) ([]T, error) {
+	build, err := handle(ctx, client, in)
+	if err != nil {
+		return nil, err
+	}
+	return api.Collect(func(ich chan<- T) error {
+		eg, ctx := errgroup.WithContext(ctx)
+		l := rate.NewLimiter(rate.Limit(50), 1)
+		eg.Go(func() error {
+			return list(ctx, client, in, func(item I) error {
+				eg.Go(func() error {
+					if err := l.Wait(ctx); err != nil {
+						return err
+					}
+					return build(ctx, ich, item)
+				})
+				return nil
+			})
+		})
+		return eg.Wait()
+	})
+}
@@ -1,8 +1,3 @@
-//go:generate go run ec2_gen.go
-//go:generate go run iam_gen.go
-//go:generate go run s3_gen.go
-//go:generate gofmt -w .
-
 package describer
 
 import (
@@ -11,7 +6,9 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/nekrassov01/aws-describer/internal/api"
+	ec2api "github.com/nekrassov01/aws-describer/internal/api/ec2"
 	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	describerpkg "github.com/nekrassov01/aws-describer/pkg/describer"
 	"github.com/nekrassov01/mintab"
 	"github.com/urfave/cli/v2"
 )
@@ -38,9 +35,30 @@ type dest struct {
 	ignore           cli.IntSlice
 	document         bool
 	documentFilter   cli.StringSlice
-	ec2Filter        string
+	ec2Filter        stringValues
+	ec2FilterFile    string
+	ec2FilterVar     stringValues
 	ec2DefaultFilter bool
 	iamPolicyScope   string
+	tag              stringValues
+	showTags         cli.StringSlice
+	nameTag          cli.StringSlice
+	unattached       bool
+	olderThan        int
+	unassociated     bool
+	unused           bool
+	auditRules       string
+	expandPrefixList bool
+	source           string
+	destination      string
+	protocol         string
+	port             int
+	minUtilization   float64
+	vpc              string
+	prefix           int
+	count            int
+	zones            cli.StringSlice
+	schemaOutput     string
 }
 
 type flag struct {
@@ -56,9 +74,31 @@ type flag struct {
 	ignore           *cli.IntSliceFlag
 	document         *cli.BoolFlag
 	documentFilter   *cli.StringSliceFlag
-	ec2Filter        *cli.StringFlag
+	ec2Filter        *cli.GenericFlag
+	ec2FilterFile    *cli.StringFlag
+	ec2FilterVar     *cli.GenericFlag
 	ec2DefaultFilter *cli.BoolFlag
 	iamPolicyScope   *cli.StringFlag
+	tag              *cli.GenericFlag
+	showTags         *cli.StringSliceFlag
+	nameTag          *cli.StringSliceFlag
+	unattached       *cli.BoolFlag
+	olderThan        *cli.IntFlag
+	unassociated     *cli.BoolFlag
+	unused           *cli.BoolFlag
+	auditRules       *cli.StringFlag
+	expandPrefixList *cli.BoolFlag
+	source           *cli.StringFlag
+	destination      *cli.StringFlag
+	protocol         *cli.StringFlag
+	port             *cli.IntFlag
+	minUtilization   *cli.Float64Flag
+	vpc              *cli.StringFlag
+	prefix           *cli.IntFlag
+	count            *cli.IntFlag
+	zones            *cli.StringSliceFlag
+	schemaJoin       *cli.StringFlag
+	schemaOutput     *cli.StringFlag
 }
 
 func New() *app {
@@ -138,11 +178,24 @@ func New() *app {
 		Usage:       "set words to filter policy documents",
 		Destination: &a.dest.documentFilter,
 	}
-	a.flag.ec2Filter = &cli.StringFlag{
-		Name:        "filter",
-		Aliases:     []string{"f"},
-		Usage:       "set ec2 filter: '{name: \"key\", values: [\"value1\", \"value2\"]}'",
-		Destination: &a.dest.ec2Filter,
+	a.flag.ec2Filter = &cli.GenericFlag{
+		Name:    "filter",
+		Aliases: []string{"f"},
+		Usage:   "set ec2 filter, repeatable: 'key=value1,value2' or '{name: \"key\", values: [\"value1\", \"value2\"]}'",
+		Value:   &a.dest.ec2Filter,
+	}
+	a.flag.ec2FilterFile = &cli.StringFlag{
+		Name:        "filter-file",
+		Aliases:     []string{"F"},
+		Usage:       "set jsonnet file evaluating to ec2 filters",
+		Destination: &a.dest.ec2FilterFile,
+		TakesFile:   true,
+	}
+	a.flag.ec2FilterVar = &cli.GenericFlag{
+		Name:    "filter-var",
+		Aliases: []string{"V"},
+		Usage:   "set jsonnet external variable for ec2 filters, repeatable: 'key=value'",
+		Value:   &a.dest.ec2FilterVar,
 	}
 	a.flag.ec2DefaultFilter = &cli.BoolFlag{
 		Name:        "default-filter",
@@ -158,60 +211,130 @@ func New() *app {
 		Destination: &a.dest.iamPolicyScope,
 		Value:       iam.PolicyScopeTypeLocal.String(),
 	}
-	baseFlags := func(s []string) []cli.Flag {
-		a.joinFlag(s)
-		return []cli.Flag{
-			a.flag.join,
-			a.flag.output,
-			a.flag.region,
-			a.flag.profile,
-			a.flag.header,
-			a.flag.merge,
-			a.flag.ignore,
-		}
-	}
-	ec2DefaultFlags := func(s []string) []cli.Flag {
-		return append(
-			baseFlags(s),
-			a.flag.regions,
-			a.flag.ids,
-			a.flag.names,
-			a.flag.ec2Filter,
-			a.flag.ec2DefaultFilter,
-		)
-	}
-	iamDefaultFlags := func(s []string) []cli.Flag {
-		return append(
-			baseFlags(s),
-			a.flag.ids,
-			a.flag.names,
-			a.flag.document,
-			a.flag.documentFilter,
-		)
-	}
-	iamScopeFlags := func(s []string) []cli.Flag {
-		return append(
-			iamDefaultFlags(s),
-			a.flag.iamPolicyScope,
-		)
-	}
-	s3DefaultFlags := func(s []string) []cli.Flag {
-		return append(
-			baseFlags(s),
-			a.flag.regions,
-			a.flag.names,
-			a.flag.document,
-			a.flag.documentFilter,
-		)
+	a.flag.tag = &cli.GenericFlag{
+		Name:    "tag",
+		Aliases: []string{"t"},
+		Usage:   "set tag to select resources by, repeatable: 'key=value1,value2' or 'key'",
+		Value:   &a.dest.tag,
+	}
+	a.flag.showTags = &cli.StringSliceFlag{
+		Name:        "show-tags",
+		Aliases:     []string{"T"},
+		Usage:       "set tag keys to output as extra columns",
+		Destination: &a.dest.showTags,
+	}
+	a.flag.nameTag = &cli.StringSliceFlag{
+		Name:        "name-tag",
+		Aliases:     []string{"N"},
+		Usage:       "set tag keys to read resource names from in order, falling back to attributes and ids",
+		Destination: &a.dest.nameTag,
+		EnvVars:     []string{strings.ToUpper(strings.ReplaceAll(Name, "-", "_")) + "_NAME_TAG"},
+		DefaultText: strings.Join(ec2api.DefaultNameTags, ","),
+	}
+	a.flag.unattached = &cli.BoolFlag{
+		Name:        "unattached",
+		Aliases:     []string{"u"},
+		Usage:       "list only volumes not attached to any instance",
+		Destination: &a.dest.unattached,
+	}
+	a.flag.olderThan = &cli.IntFlag{
+		Name:        "older-than",
+		Aliases:     []string{"a"},
+		Usage:       "list only snapshots older than the days",
+		Destination: &a.dest.olderThan,
+	}
+	a.flag.unassociated = &cli.BoolFlag{
+		Name:        "unassociated",
+		Aliases:     []string{"u"},
+		Usage:       "list only addresses not associated with any resource",
+		Destination: &a.dest.unassociated,
+	}
+	a.flag.unused = &cli.BoolFlag{
+		Name:        "unused",
+		Aliases:     []string{"u"},
+		Usage:       "list only security groups not attached to any network interface, except default ones",
+		Destination: &a.dest.unused,
+	}
+	a.flag.auditRules = &cli.StringFlag{
+		Name:        "audit-rules",
+		Usage:       "set jsonnet file evaluating to security group audit rules: '{adminPorts: [22], broadPrefixLength: 16, severities: {WorldOpen: \"None\"}}'",
+		Destination: &a.dest.auditRules,
+		TakesFile:   true,
+	}
+	a.flag.expandPrefixList = &cli.BoolFlag{
+		Name:        "expand-prefix-lists",
+		Usage:       "expand security group rules and routes referencing a prefix list to its cidr blocks, except in redundancy joins",
+		Destination: &a.dest.expandPrefixList,
+	}
+	a.flag.source = &cli.StringFlag{
+		Name:        "source",
+		Usage:       "set source of the flow: instance id, network interface id, ip address or cidr block",
+		Destination: &a.dest.source,
+	}
+	a.flag.destination = &cli.StringFlag{
+		Name:        "destination",
+		Usage:       "set destination of the flow: instance id, network interface id, ip address or cidr block",
+		Destination: &a.dest.destination,
+	}
+	a.flag.protocol = &cli.StringFlag{
+		Name:        "protocol",
+		Usage:       "set protocol of the flow: tcp|udp|icmp|icmpv6|all|<number>",
+		Destination: &a.dest.protocol,
+		Value:       "tcp",
+	}
+	a.flag.port = &cli.IntFlag{
+		Name:        "port",
+		Usage:       "set destination port of the flow, required for tcp and udp",
+		Destination: &a.dest.port,
+	}
+	a.flag.minUtilization = &cli.Float64Flag{
+		Name:        "min-utilization",
+		Usage:       "list only subnets or vpcs using at least the percentage of their ipv4 addresses",
+		Destination: &a.dest.minUtilization,
+	}
+	a.flag.vpc = &cli.StringFlag{
+		Name:        "vpc",
+		Usage:       "set vpc id to plan subnets in",
+		Destination: &a.dest.vpc,
+	}
+	a.flag.prefix = &cli.IntFlag{
+		Name:        "prefix",
+		Usage:       "set prefix length of the subnets to plan, between 16 and 28",
+		Destination: &a.dest.prefix,
+		Value:       24,
+	}
+	a.flag.count = &cli.IntFlag{
+		Name:        "count",
+		Usage:       "set number of subnets to plan",
+		Destination: &a.dest.count,
+		Value:       1,
+	}
+	a.flag.zones = &cli.StringSliceFlag{
+		Name:        "availability-zones",
+		Usage:       "set availability zones to assign the planned subnets to in turn",
+		Destination: &a.dest.zones,
+	}
+	a.flag.schemaJoin = &cli.StringFlag{
+		Name:        "join",
+		Aliases:     []string{"j"},
+		Usage:       "set join to describe",
+		Destination: &a.dest.join,
+	}
+	a.flag.schemaOutput = &cli.StringFlag{
+		Name:        "output",
+		Aliases:     []string{"o"},
+		Usage:       fmt.Sprintf("select output format: %s|%s", strings.Join(mintab.Formats, "|"), schemaOutputJSON),
+		Destination: &a.dest.schemaOutput,
+		Value:       mintab.FormatText.String(),
 	}
 	a.App = &cli.App{
 		Name:                 Name,
 		Usage:                "AWS resources describer CLI",
-		Version:              Version,
+		Version:              describerpkg.Version,
 		Description:          "A cli application to join and list AWS resources with various other resources",
 		HideHelpCommand:      true,
 		EnableBashCompletion: true,
-		Commands: []*cli.Command{
+		Commands: append([]*cli.Command{
 			{
 				Name:            "completion",
 				Description:     "Generate completion scripts",
@@ -220,149 +343,12 @@ func New() *app {
 				HideHelpCommand: true,
 				Action:          a.doCompletion,
 			},
-			{
-				Name:            "ec2",
-				Description:     "Invoke EC2 API and list resources in various output formats",
-				Usage:           "Invoke EC2 API and list resources",
-				UsageText:       fmt.Sprintf("%s ec2 command", Name),
-				HideHelpCommand: true,
-				Before:          a.doBefore,
-				Subcommands: []*cli.Command{
-					{
-						Name:            "get-instances",
-						Description:     "List EC2 instance info in combination with various resources",
-						Usage:           "List EC2 instance info",
-						UsageText:       fmt.Sprintf("%s ec2 get-instances", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2InstanceActionMembers),
-						Action:          a.doEc2Instance,
-					},
-					{
-						Name:            "get-images",
-						Description:     "List EC2 image info in combination with various resources",
-						Usage:           "List EC2 image info",
-						UsageText:       fmt.Sprintf("%s ec2 get-images", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2ImageActionMembers),
-						Action:          a.doEc2Image,
-					},
-					{
-						Name:            "get-security-groups",
-						Description:     "List EC2 security group info in combination with various resources",
-						Usage:           "List EC2 security group info",
-						UsageText:       fmt.Sprintf("%s ec2 get-security-groups", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2SecurityGroupActionMembers),
-						Action:          a.doEc2SecurityGroup,
-					},
-					{
-						Name:            "get-vpcs",
-						Description:     "List EC2 VPC info in combination with various resources",
-						Usage:           "List EC2 VPC info",
-						UsageText:       fmt.Sprintf("%s ec2 get-vpcs", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2VpcActionMembers),
-						Action:          a.doEc2Vpc,
-					},
-					{
-						Name:            "get-subnets",
-						Description:     "List EC2 subnet info in combination with various resources",
-						Usage:           "List EC2 subnet info",
-						UsageText:       fmt.Sprintf("%s ec2 get-subnets", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2SubnetActionMembers),
-						Action:          a.doEc2Subnet,
-					},
-					{
-						Name:            "get-route-tables",
-						Description:     "List EC2 route table info in combination with various resources",
-						Usage:           "List EC2 route table info",
-						UsageText:       fmt.Sprintf("%s ec2 get-route-tables", Name),
-						HideHelpCommand: true,
-						Flags:           ec2DefaultFlags(ec2RouteTableActionMembers),
-						Action:          a.doEc2RouteTable,
-					},
-				},
-			},
-			{
-				Name:            "iam",
-				Description:     "Invoke IAM API and list resources in various output formats",
-				Usage:           "Invoke IAM API and list resources",
-				UsageText:       fmt.Sprintf("%s iam command", Name),
-				HideHelpCommand: true,
-				Before:          a.doBefore,
-				Subcommands: []*cli.Command{
-					{
-						Name:            "get-users",
-						Description:     "List IAM user info in combination with related info",
-						Usage:           "List IAM user info",
-						UsageText:       fmt.Sprintf("%s iam get-users", Name),
-						HideHelpCommand: true,
-						Flags:           iamDefaultFlags(iamUserActionMembers),
-						Action:          a.doIamUser,
-					},
-					{
-						Name:            "get-groups",
-						Description:     "List IAM group info in combination with related info",
-						Usage:           "List IAM group info",
-						UsageText:       fmt.Sprintf("%s iam get-groups", Name),
-						HideHelpCommand: true,
-						Flags:           iamDefaultFlags(iamGroupActionMembers),
-						Action:          a.doIamGroup,
-					},
-					{
-						Name:            "get-roles",
-						Description:     "List IAM role info in combination with related info",
-						Usage:           "List IAM role info",
-						UsageText:       fmt.Sprintf("%s iam get-roles", Name),
-						HideHelpCommand: true,
-						Flags:           iamDefaultFlags(iamRoleActionMembers),
-						Action:          a.doIamRole,
-					},
-					{
-						Name:            "get-policies",
-						Description:     "List IAM policy info in combination with related info",
-						Usage:           "List IAM policy info",
-						UsageText:       fmt.Sprintf("%s iam get-policies", Name),
-						HideHelpCommand: true,
-						Flags:           iamScopeFlags(iamPolicyActionMembers),
-						Action:          a.doIamPolicy,
-					},
-				},
-			},
-			{
-				Name:            "s3",
-				Description:     "Invoke S3 API and list resources in various output formats",
-				Usage:           "Invoke S3 API and list resources",
-				UsageText:       fmt.Sprintf("%s s3 command", Name),
-				HideHelpCommand: true,
-				Before:          a.doBefore,
-				Subcommands: []*cli.Command{
-					{
-						Name:            "get-buckets",
-						Description:     "List S3 bucket info in combination with related info",
-						Usage:           "List S3 bucket info",
-						UsageText:       fmt.Sprintf("%s s3 get-buckets", Name),
-						HideHelpCommand: true,
-						Flags:           s3DefaultFlags(s3BucketActionMembers),
-						Action:          a.doS3Bucket,
-					},
-				},
-			},
-		},
+			a.schemaCommand(),
+		}, a.serviceCommands()...),
 	}
 	return &a
 }
 
-func (a *app) joinFlag(s []string) {
-	a.flag.join = &cli.StringFlag{
-		Name:        "join",
-		Aliases:     []string{"j"},
-		Usage:       fmt.Sprintf("set info to be joined: %s", strings.Join(s, "|")),
-		Destination: &a.dest.join,
-	}
-}
-
 func (a *app) doBefore(c *cli.Context) error {
 	cfg, err := api.LoadConfig(c.Context, a.dest.region, a.dest.profile)
 	if err != nil {
@@ -0,0 +1,180 @@
+package describer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+	"github.com/urfave/cli/v2"
+)
+
+func (a *app) serviceCommands() []*cli.Command {
+	var cmds []*cli.Command
+	for _, s := range registry.Services() {
+		sub := make([]*cli.Command, 0, len(s.Commands))
+		for _, cmd := range s.Commands {
+			sub = append(sub, a.command(s, cmd))
+		}
+		cmds = append(cmds, &cli.Command{
+			Name:            s.Name,
+			Description:     s.Description,
+			Usage:           s.Usage,
+			UsageText:       fmt.Sprintf("%s %s command", Name, s.Name),
+			HideHelpCommand: true,
+			Before:          a.doBefore,
+			Subcommands:     sub,
+		})
+	}
+	return cmds
+}
+
+func (a *app) command(s *registry.Service, cmd *registry.Command) *cli.Command {
+	a.joinFlag(cmd.JoinNames())
+	flags := []cli.Flag{
+		a.flag.join,
+		a.flag.output,
+		a.flag.region,
+		a.flag.profile,
+		a.flag.header,
+		a.flag.merge,
+		a.flag.ignore,
+	}
+	for _, f := range cmd.Flags {
+		flags = append(flags, a.cliFlag(f))
+	}
+	c := &cli.Command{
+		Name:            cmd.Name,
+		Description:     cmd.Description,
+		Usage:           cmd.Usage,
+		UsageText:       fmt.Sprintf("%s %s %s", Name, s.Name, cmd.Name),
+		HideHelpCommand: true,
+		Flags:           flags,
+		Action:          a.doCommand(cmd),
+	}
+	c.BashComplete = a.completeJoin(c, cmd)
+	return c
+}
+
+func (a *app) joinFlag(s []string) {
+	a.flag.join = &cli.StringFlag{
+		Name:        "join",
+		Aliases:     []string{"j"},
+		Usage:       fmt.Sprintf("set info to be joined: %s", strings.Join(s, "|")),
+		Destination: &a.dest.join,
+	}
+}
+
+func (a *app) cliFlag(f registry.Flag) cli.Flag {
+	switch f {
+	case registry.FlagRegions:
+		return a.flag.regions
+	case registry.FlagIds:
+		return a.flag.ids
+	case registry.FlagNames:
+		return a.flag.names
+	case registry.FlagFilter:
+		return a.flag.ec2Filter
+	case registry.FlagFilterFile:
+		return a.flag.ec2FilterFile
+	case registry.FlagFilterVar:
+		return a.flag.ec2FilterVar
+	case registry.FlagDefaultFilter:
+		return a.flag.ec2DefaultFilter
+	case registry.FlagDocument:
+		return a.flag.document
+	case registry.FlagDocumentFilter:
+		return a.flag.documentFilter
+	case registry.FlagScope:
+		return a.flag.iamPolicyScope
+	case registry.FlagTag:
+		return a.flag.tag
+	case registry.FlagShowTags:
+		return a.flag.showTags
+	case registry.FlagNameTag:
+		return a.flag.nameTag
+	case registry.FlagUnattached:
+		return a.flag.unattached
+	case registry.FlagOlderThan:
+		return a.flag.olderThan
+	case registry.FlagUnassociated:
+		return a.flag.unassociated
+	case registry.FlagUnused:
+		return a.flag.unused
+	case registry.FlagAuditRules:
+		return a.flag.auditRules
+	case registry.FlagExpandPrefixLists:
+		return a.flag.expandPrefixList
+	case registry.FlagSource:
+		return a.flag.source
+	case registry.FlagDestination:
+		return a.flag.destination
+	case registry.FlagProtocol:
+		return a.flag.protocol
+	case registry.FlagPort:
+		return a.flag.port
+	case registry.FlagMinUtilization:
+		return a.flag.minUtilization
+	case registry.FlagVpc:
+		return a.flag.vpc
+	case registry.FlagPrefix:
+		return a.flag.prefix
+	case registry.FlagCount:
+		return a.flag.count
+	case registry.FlagAvailabilityZones:
+		return a.flag.zones
+	default:
+		panic(fmt.Sprintf("unknown flag: %d", f))
+	}
+}
+
+func (a *app) doCommand(cmd *registry.Command) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		j, err := cmd.Find(a.dest.join)
+		if err != nil {
+			return err
+		}
+		if err := a.validate(c, cmd, j); err != nil {
+			return err
+		}
+		opts, err := a.options(cmd)
+		if err != nil {
+			return err
+		}
+		return j.Print(c.Context, opts, a.dest.output, a.dest.header, a.flag.merge.GetDestination(), a.flag.ignore.GetDestination())
+	}
+}
+
+func (a *app) validate(c *cli.Context, cmd *registry.Command, j registry.Joiner) error {
+	if cmd.HasFlag(registry.FlagDocument) {
+		if !j.HasDocument() && (c.IsSet(a.flag.document.Name) || c.IsSet(a.flag.documentFilter.Name)) {
+			return fmt.Errorf("invalid args/flags combination: \"%s\" and \"%s\" are valid only when \"%s\" is selected at \"%s\"", a.flag.document.Name, a.flag.documentFilter.Name, strings.Join(cmd.DocumentJoinNames(), "|"), a.flag.join.Name)
+		}
+		if !c.IsSet(a.flag.document.Name) && c.IsSet(a.flag.documentFilter.Name) {
+			return fmt.Errorf("invalid args/flags combination: \"%s\" is valid only when \"%s\" is enabled", a.flag.documentFilter.Name, a.flag.document.Name)
+		}
+	}
+	if cmd.HasFlag(registry.FlagScope) {
+		if a.dest.iamPolicyScope != iam.PolicyScopeTypeLocal.String() && (len(a.flag.ids.GetDestination()) == 0 && len(a.flag.names.GetDestination()) == 0) {
+			return fmt.Errorf("invalid args/flags combination: \"%s\" or \"%s\" are required when \"%s\" is not \"%s\"", a.flag.ids.Name, a.flag.names.Name, a.flag.iamPolicyScope.Name, iam.PolicyScopeTypeLocal.String())
+		}
+	}
+	return nil
+}
+
+func (a *app) completeJoin(c *cli.Command, cmd *registry.Command) cli.BashCompleteFunc {
+	complete := cli.DefaultCompleteWithFlags(c)
+	return func(ctx *cli.Context) {
+		if len(os.Args) > 2 {
+			switch os.Args[len(os.Args)-2] {
+			case "--" + a.flag.join.Name, "-" + a.flag.join.Aliases[0]:
+				for _, name := range cmd.JoinNames() {
+					fmt.Fprintln(ctx.App.Writer, name)
+				}
+				return
+			}
+		}
+		complete(ctx)
+	}
+}
@@ -0,0 +1,167 @@
+package describer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ec2api "github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+)
+
+// stringValues is a repeatable flag value kept as passed, without splitting on commas.
+type stringValues []string
+
+func (v *stringValues) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
+func (v *stringValues) String() string {
+	if v == nil {
+		return ""
+	}
+	return strings.Join(*v, " ")
+}
+
+func (a *app) options(cmd *registry.Command) (*registry.Options, error) {
+	opts := &registry.Options{
+		Config: a.config,
+	}
+	for _, f := range cmd.Flags {
+		switch f {
+		case registry.FlagRegions:
+			opts.Regions = a.flag.regions.GetDestination()
+		case registry.FlagIds:
+			opts.Ids = a.flag.ids.GetDestination()
+		case registry.FlagNames:
+			opts.Names = a.flag.names.GetDestination()
+		case registry.FlagFilter:
+			filters, err := a.ec2Filters()
+			if err != nil {
+				return nil, err
+			}
+			opts.Filters = filters
+		case registry.FlagDefaultFilter:
+			opts.DefaultFilter = a.dest.ec2DefaultFilter
+		case registry.FlagDocument:
+			opts.Document = a.dest.document
+		case registry.FlagDocumentFilter:
+			opts.DocumentFilters = a.flag.documentFilter.GetDestination()
+		case registry.FlagScope:
+			opts.Scope = a.dest.iamPolicyScope
+		case registry.FlagTag:
+			tags, err := a.tags()
+			if err != nil {
+				return nil, err
+			}
+			opts.Tags = tags
+		case registry.FlagShowTags:
+			opts.ShowTags = a.flag.showTags.GetDestination()
+		case registry.FlagNameTag:
+			opts.NameTags = a.flag.nameTag.GetDestination()
+		case registry.FlagUnattached:
+			opts.Unattached = a.dest.unattached
+		case registry.FlagOlderThan:
+			if a.dest.olderThan < 0 {
+				return nil, fmt.Errorf("invalid value: %d: %s must not be negative", a.dest.olderThan, a.flag.olderThan.Name)
+			}
+			opts.OlderThan = time.Duration(a.dest.olderThan) * 24 * time.Hour
+		case registry.FlagUnassociated:
+			opts.Unassociated = a.dest.unassociated
+		case registry.FlagUnused:
+			opts.Unused = a.dest.unused
+		case registry.FlagAuditRules:
+			if a.dest.auditRules == "" {
+				continue
+			}
+			rules, err := ec2api.ParseEc2AuditRules(a.dest.auditRules)
+			if err != nil {
+				return nil, err
+			}
+			opts.AuditRules = rules
+		case registry.FlagExpandPrefixLists:
+			opts.ExpandPrefixLists = a.dest.expandPrefixList
+		case registry.FlagSource:
+			opts.Source = a.dest.source
+		case registry.FlagDestination:
+			opts.Destination = a.dest.destination
+		case registry.FlagProtocol:
+			opts.Protocol = a.dest.protocol
+		case registry.FlagPort:
+			if a.dest.port < 0 || a.dest.port > 65535 {
+				return nil, fmt.Errorf("invalid value: %d: %s must be between 0 and 65535", a.dest.port, a.flag.port.Name)
+			}
+			opts.Port = int32(a.dest.port)
+		case registry.FlagMinUtilization:
+			if a.dest.minUtilization < 0 || a.dest.minUtilization > 100 {
+				return nil, fmt.Errorf("invalid value: %g: %s must be between 0 and 100", a.dest.minUtilization, a.flag.minUtilization.Name)
+			}
+			opts.MinUtilization = a.dest.minUtilization
+		case registry.FlagVpc:
+			opts.Vpc = a.dest.vpc
+		case registry.FlagPrefix:
+			opts.Prefix = a.dest.prefix
+		case registry.FlagCount:
+			if a.dest.count < 1 {
+				return nil, fmt.Errorf("invalid value: %d: %s must be positive", a.dest.count, a.flag.count.Name)
+			}
+			opts.Count = a.dest.count
+		case registry.FlagAvailabilityZones:
+			opts.AvailabilityZones = a.flag.zones.GetDestination()
+		}
+	}
+	return opts, nil
+}
+
+// ec2Filters combines the filters of the filter file with the ones passed in filter flags.
+func (a *app) ec2Filters() ([]types.Filter, error) {
+	vars := make(map[string]string, len(a.dest.ec2FilterVar))
+	for _, s := range a.dest.ec2FilterVar {
+		k, v, ok := strings.Cut(s, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("cannot parse value passed in %s: must be key=value: %s", a.flag.ec2FilterVar.Name, s)
+		}
+		vars[k] = v
+	}
+	var filters []types.Filter
+	if a.dest.ec2FilterFile != "" {
+		f, err := ec2api.ParseEc2FilterFile(a.dest.ec2FilterFile, vars)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f...)
+	}
+	for _, s := range a.dest.ec2Filter {
+		f, err := ec2api.ParseEc2Filters(s, vars)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f...)
+	}
+	return filters, nil
+}
+
+// tags parses the tag selectors, merging the values of the same key.
+func (a *app) tags() (map[string][]string, error) {
+	if len(a.dest.tag) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string][]string, len(a.dest.tag))
+	for _, s := range a.dest.tag {
+		k, v, _ := strings.Cut(s, "=")
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, fmt.Errorf("cannot parse value passed in %s: empty key: %s", a.flag.tag.Name, s)
+		}
+		if _, ok := tags[k]; !ok {
+			tags[k] = nil
+		}
+		for _, v := range strings.Split(v, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				tags[k] = append(tags[k], v)
+			}
+		}
+	}
+	return tags, nil
+}
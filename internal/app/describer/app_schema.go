@@ -0,0 +1,81 @@
+package describer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nekrassov01/aws-describer/internal/registry"
+	"github.com/nekrassov01/aws-describer/internal/tab"
+	"github.com/urfave/cli/v2"
+)
+
+const schemaOutputJSON = "json"
+
+func (a *app) schemaCommand() *cli.Command {
+	flags := []cli.Flag{
+		a.flag.schemaOutput,
+		a.flag.header,
+	}
+	var svcs []*cli.Command
+	for _, s := range registry.Services() {
+		var cmds []*cli.Command
+		for _, cmd := range s.Commands {
+			c := &cli.Command{
+				Name:            cmd.Name,
+				Description:     fmt.Sprintf("List the columns of the joins of %s %s", s.Name, cmd.Name),
+				Usage:           fmt.Sprintf("List columns of %s %s", s.Name, cmd.Name),
+				UsageText:       fmt.Sprintf("%s schema %s %s", Name, s.Name, cmd.Name),
+				HideHelpCommand: true,
+				Flags:           append([]cli.Flag{a.flag.schemaJoin}, flags...),
+				Action:          a.doSchema(s.Name, cmd.Name),
+			}
+			c.BashComplete = a.completeJoin(c, cmd)
+			cmds = append(cmds, c)
+		}
+		svcs = append(svcs, &cli.Command{
+			Name:            s.Name,
+			Description:     fmt.Sprintf("List the columns of the joins of %s commands", s.Name),
+			Usage:           fmt.Sprintf("List columns of %s commands", s.Name),
+			UsageText:       fmt.Sprintf("%s schema %s [command]", Name, s.Name),
+			HideHelpCommand: true,
+			Flags:           flags,
+			Subcommands:     cmds,
+			Action:          a.doSchema(s.Name, ""),
+		})
+	}
+	return &cli.Command{
+		Name:            "schema",
+		Description:     "List the columns of every join with their index, name and type",
+		Usage:           "List columns of joins",
+		UsageText:       fmt.Sprintf("%s schema [service [command]]", Name),
+		HideHelpCommand: true,
+		Flags:           flags,
+		Subcommands:     svcs,
+		Action:          a.doSchema("", ""),
+	}
+}
+
+func (a *app) doSchema(service, command string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.Args().Present() {
+			return fmt.Errorf("invalid args: %s: usage: %s", c.Args().First(), c.Command.UsageText)
+		}
+		join := ""
+		if command != "" {
+			join = a.dest.join
+		}
+		schema, err := registry.Schema(service, command, join)
+		if err != nil {
+			return err
+		}
+		if a.dest.schemaOutput == schemaOutputJSON {
+			b, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return fmt.Errorf("cannot output result: %w", err)
+			}
+			fmt.Fprintln(c.App.Writer, string(b))
+			return nil
+		}
+		return tab.PrintTable(registry.Flatten(schema), a.dest.schemaOutput, a.dest.header, []int{0, 1, 2}, nil)
+	}
+}
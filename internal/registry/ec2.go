@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"context"
+	"slices"
+
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var ec2Service = &Service{
+	Name:        "ec2",
+	Usage:       "Invoke EC2 API and list resources",
+	Description: "Invoke EC2 API and list resources in various output formats",
+	Commands: []*Command{
+		instanceCommand,
+		imageCommand,
+		securityGroupCommand,
+		vpcCommand,
+		subnetCommand,
+		routeTableCommand,
+		networkAclCommand,
+		networkInterfaceCommand,
+		volumeCommand,
+		snapshotCommand,
+		addressCommand,
+		transitGatewayCommand,
+		vpcPeeringConnectionCommand,
+		reachCommand,
+		planSubnetsCommand,
+	},
+}
+
+var ec2Flags = []Flag{
+	FlagRegions,
+	FlagIds,
+	FlagNames,
+	FlagFilter,
+	FlagFilterFile,
+	FlagFilterVar,
+	FlagDefaultFilter,
+	FlagTag,
+	FlagShowTags,
+	FlagNameTag,
+}
+
+func describeEc2[I, T any](list *ec2.Lister[I], handle ec2.Handler[I, T]) func(ctx context.Context, opts *Options) ([]T, error) {
+	return func(ctx context.Context, opts *Options) ([]T, error) {
+		client, err := opts.ec2Client()
+		if err != nil {
+			return nil, err
+		}
+		in := &ec2.Input{
+			Ids:               opts.Ids,
+			Names:             opts.Names,
+			Filters:           slices.Concat(opts.Filters, tagFilters(opts.Tags)),
+			DefaultFilter:     opts.DefaultFilter,
+			Namer:             &ec2.Namer{Tags: opts.NameTags},
+			Unattached:        opts.Unattached,
+			OlderThan:         opts.OlderThan,
+			Unassociated:      opts.Unassociated,
+			Unused:            opts.Unused,
+			AuditRules:        opts.AuditRules,
+			ExpandPrefixLists: opts.ExpandPrefixLists,
+			MinUtilization:    opts.MinUtilization,
+		}
+		return ec2.Describe(ctx, client, opts.regions(), in, list, handle)
+	}
+}
+
+// fetchPrefixListEntries fetches the entries of the prefix lists of the region when the input expands them.
+func fetchPrefixListEntries(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.PrefixListEntries, error) {
+	if !in.ExpandPrefixLists {
+		return nil, nil
+	}
+	return ec2.FetchPrefixListEntries(ctx, l, client, region)
+}
@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var addressCommand = &Command{
+	Name:        "get-addresses",
+	Usage:       "List EC2 elastic IP address info",
+	Description: "List EC2 elastic IP address info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagUnassociated}),
+	Joins: []Joiner{
+		Address,
+		AddressAssociation,
+	},
+}
+
+var Address = &Join[ec2.AddressInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.AddressLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Address, ec2.AddressInfo], error) {
+		nats, err := client.FetchNatGateways(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.AddressInfo, items []types.Address) error {
+			ec2.GetAddressInfo(ich, items, region, nats, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.AddressInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.AssociationType, b.AssociationType),
+			cmp.Compare(a.AddressName, b.AddressName),
+			cmp.Compare(a.PublicIp, b.PublicIp),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.AddressInfo) string {
+		return row.AllocationId
+	}),
+}
+
+var AddressAssociation = &Join[ec2.AddressAssociationInfo]{
+	Name: "assoc",
+	Describe: describeEc2(ec2.AddressLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Address, ec2.AddressAssociationInfo], error) {
+		instances, nats, enis, err := ec2.FetchDataForAddressAssociationInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.AddressAssociationInfo, items []types.Address) error {
+			ec2.GetAddressAssociationInfo(ich, items, region, instances, nats, enis, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.AddressAssociationInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.AssociationType, b.AssociationType),
+			cmp.Compare(a.AssociatedName, b.AssociatedName),
+			cmp.Compare(a.PublicIp, b.PublicIp),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.AddressAssociationInfo) string {
+		return row.AllocationId
+	}),
+}
@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var networkInterfaceCommand = &Command{
+	Name:        "get-network-interfaces",
+	Usage:       "List EC2 network interface info",
+	Description: "List EC2 network interface info in combination with various resources",
+	Flags:       ec2Flags,
+	Joins: []Joiner{
+		NetworkInterface,
+		NetworkInterfaceAttachment,
+		NetworkInterfaceSecurityGroup,
+		NetworkInterfaceIp,
+	},
+}
+
+var NetworkInterface = &Join[ec2.NetworkInterfaceInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.NetworkInterfaceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkInterface, ec2.NetworkInterfaceInfo], error) {
+		vpcs, sbns, err := ec2.FetchDataForNetworkInterfaceInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkInterfaceInfo, items []types.NetworkInterface) error {
+			return ec2.GetNetworkInterfaceInfo(ich, items, region, vpcs, sbns, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkInterfaceInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.NetworkInterfaceName, b.NetworkInterfaceName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.NetworkInterfaceInfo) string {
+		return row.NetworkInterfaceId
+	}),
+}
+
+var NetworkInterfaceAttachment = &Join[ec2.NetworkInterfaceAttachmentInfo]{
+	Name: "attachment",
+	Describe: describeEc2(ec2.NetworkInterfaceLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkInterface, ec2.NetworkInterfaceAttachmentInfo], error) {
+		instances, err := client.FetchInstances(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkInterfaceAttachmentInfo, items []types.NetworkInterface) error {
+			ec2.GetNetworkInterfaceAttachmentInfo(ich, items, region, instances, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkInterfaceAttachmentInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.Owner, b.Owner),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.DeviceIndex, b.DeviceIndex),
+			cmp.Compare(a.NetworkInterfaceName, b.NetworkInterfaceName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.NetworkInterfaceAttachmentInfo) string {
+		return row.NetworkInterfaceId
+	}),
+}
+
+var NetworkInterfaceSecurityGroup = &Join[ec2.NetworkInterfaceSecurityGroupInfo]{
+	Name: "sg",
+	Describe: describeEc2(ec2.NetworkInterfaceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkInterface, ec2.NetworkInterfaceSecurityGroupInfo], error) {
+		segs, vpcs, upls, mpls, err := ec2.FetchDataForNetworkInterfaceSecurityGroupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkInterfaceSecurityGroupInfo, items []types.NetworkInterface) error {
+			return ec2.GetNetworkInterfaceSecurityGroupInfo(ich, items, region, segs, vpcs, upls, mpls, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkInterfaceSecurityGroupInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.NetworkInterfaceName, b.NetworkInterfaceName),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.NetworkInterfaceSecurityGroupInfo) string {
+		return row.NetworkInterfaceId
+	}),
+}
+
+var NetworkInterfaceIp = &Join[ec2.NetworkInterfaceIpInfo]{
+	Name: "ip",
+	Describe: describeEc2(ec2.NetworkInterfaceLister, func(_ context.Context, _ *rate.Limiter, _ ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkInterface, ec2.NetworkInterfaceIpInfo], error) {
+		return func(ich chan<- ec2.NetworkInterfaceIpInfo, items []types.NetworkInterface) error {
+			ec2.GetNetworkInterfaceIpInfo(ich, items, region, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkInterfaceIpInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.NetworkInterfaceName, b.NetworkInterfaceName),
+			cmp.Compare(a.AddressType, b.AddressType),
+			compareBool(b.Primary, a.Primary),
+			cmp.Compare(a.PrivateIpAddress, b.PrivateIpAddress),
+		)
+	},
+	MergeFields: []int{0, 1, 2},
+	Tags: ec2Tags(func(row ec2.NetworkInterfaceIpInfo) string {
+		return row.NetworkInterfaceId
+	}),
+}
@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var imageCommand = &Command{
+	Name:        "get-images",
+	Usage:       "List EC2 image info",
+	Description: "List EC2 image info in combination with various resources",
+	Flags:       ec2Flags,
+	Joins: []Joiner{
+		Image,
+		ImageBackup,
+	},
+}
+
+var Image = &Join[ec2.ImageInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.ImageLister, func(_ context.Context, _ *rate.Limiter, _ ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Image, ec2.ImageInfo], error) {
+		return func(ich chan<- ec2.ImageInfo, items []types.Image) error {
+			ec2.GetImageInfo(ich, items, region)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.ImageInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.ImageName, b.ImageName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.ImageInfo) string {
+		return row.ImageId
+	}),
+}
+
+var ImageBackup = &Join[ec2.ImageBackupInfo]{
+	Name: "backup",
+	Describe: describeEc2(ec2.ImageLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Image, ec2.ImageBackupInfo], error) {
+		snps, vols, err := ec2.FetchDataForImageBackupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.ImageBackupInfo, items []types.Image) error {
+			ec2.GetImageBackupInfo(ich, items, region, snps, vols, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.ImageBackupInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.ImageName, b.ImageName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.ImageBackupInfo) string {
+		return row.ImageId
+	}),
+}
@@ -0,0 +1,307 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var instanceCommand = &Command{
+	Name:        "get-instances",
+	Usage:       "List EC2 instance info",
+	Description: "List EC2 instance info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagAuditRules, FlagExpandPrefixLists}),
+	Joins: []Joiner{
+		Instance,
+		InstanceSecurityGroup,
+		InstanceSecurityGroupAudit,
+		InstanceSecurityGroupRedundancy,
+		InstanceExposure,
+		InstanceRoute,
+		InstanceStorage,
+		InstanceBackup,
+		InstanceLoadBalancer,
+	},
+}
+
+var Instance = &Join[ec2.InstanceInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.InstanceLister, func(_ context.Context, _ *rate.Limiter, _ ec2.IEc2Client, _ string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceInfo], error) {
+		return func(ich chan<- ec2.InstanceInfo, items []types.Reservation) error {
+			ec2.GetInstanceInfo(ich, items, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.InstanceType, b.InstanceType),
+			cmp.Compare(a.PrivateIpAddress, b.PrivateIpAddress),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.InstanceInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceSecurityGroup = &Join[ec2.InstanceSecurityGroupInfo]{
+	Name: "sg",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceSecurityGroupInfo], error) {
+		segs, vpcs, upls, mpls, err := ec2.FetchDataForInstanceSecurityGroupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceSecurityGroupInfo, items []types.Reservation) error {
+			return ec2.GetInstanceSecurityGroupInfo(ich, items, region, segs, vpcs, upls, mpls, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceSecurityGroupInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.PrefixList, b.PrefixList),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5},
+	Tags: ec2Tags(func(row ec2.InstanceSecurityGroupInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceSecurityGroupAudit = &Join[ec2.InstanceSecurityGroupAuditInfo]{
+	Name: "sg-audit",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceSecurityGroupAuditInfo], error) {
+		segs, vpcs, upls, mpls, err := ec2.FetchDataForInstanceSecurityGroupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceSecurityGroupAuditInfo, items []types.Reservation) error {
+			return ec2.GetInstanceSecurityGroupAuditInfo(ich, items, region, segs, vpcs, upls, mpls, ples, in.AuditRules, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceSecurityGroupAuditInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			ec2.CompareSeverity(a.Severity, b.Severity),
+			cmp.Compare(a.Finding, b.Finding),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5},
+	Tags: ec2Tags(func(row ec2.InstanceSecurityGroupAuditInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceSecurityGroupRedundancy = &Join[ec2.InstanceSecurityGroupRedundancyInfo]{
+	Name: "sg-redundant",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceSecurityGroupRedundancyInfo], error) {
+		segs, vpcs, upls, mpls, err := ec2.FetchDataForInstanceSecurityGroupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceSecurityGroupRedundancyInfo, items []types.Reservation) error {
+			return ec2.GetInstanceSecurityGroupRedundancyInfo(ich, items, region, segs, vpcs, upls, mpls, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceSecurityGroupRedundancyInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5},
+	Tags: ec2Tags(func(row ec2.InstanceSecurityGroupRedundancyInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceExposure = &Join[ec2.InstanceExposureInfo]{
+	Name: "exposure",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceExposureInfo], error) {
+		nw, err := ec2.FetchNetwork(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceExposureInfo, items []types.Reservation) error {
+			return ec2.GetInstanceExposureInfo(ich, items, region, nw, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceExposureInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			compareBool(b.Exposed, a.Exposed),
+			cmp.Compare(a.PublicIpAddress, b.PublicIpAddress),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+		)
+	},
+	MergeFields: []int{0, 1},
+	Tags: ec2Tags(func(row ec2.InstanceExposureInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceRoute = &Join[ec2.InstanceRouteInfo]{
+	Name: "route",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceRouteInfo], error) {
+		vpcs, sbns, rtbs, gws, err := ec2.FetchDataForInstanceRouteInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceRouteInfo, items []types.Reservation) error {
+			return ec2.GetInstanceRouteInfo(ich, items, region, vpcs, sbns, rtbs, gws, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceRouteInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.DestinationType, b.DestinationType),
+			cmp.Compare(a.TargetType, b.TargetType),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+	Tags: ec2Tags(func(row ec2.InstanceRouteInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceStorage = &Join[ec2.InstanceStorageInfo]{
+	Name: "storage",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceStorageInfo], error) {
+		vols, err := client.FetchVolumes(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceStorageInfo, items []types.Reservation) error {
+			ec2.GetInstanceStorageInfo(ich, items, vols, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceStorageInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.DeviceName, b.DeviceName),
+			cmp.Compare(a.VolumeName, b.VolumeName),
+			cmp.Compare(a.VolumeType, b.VolumeType),
+			cmp.Compare(a.VolumeSize, b.VolumeSize),
+			cmp.Compare(a.IOPS, b.IOPS),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.InstanceStorageInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceBackup = &Join[ec2.InstanceBackupInfo]{
+	Name: "backup",
+	Describe: describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceBackupInfo], error) {
+		imgs, snps, vols, err := ec2.FetchDataForInstanceBackupInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.InstanceBackupInfo, items []types.Reservation) error {
+			ec2.GetInstanceBackupInfo(ich, items, imgs, snps, vols, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.InstanceBackupInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.ImageOwner, b.ImageOwner),
+			cmp.Compare(a.ImageName, b.ImageName),
+			cmp.Compare(a.SnapshotName, b.SnapshotName),
+			cmp.Compare(a.VolumeName, b.VolumeName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.InstanceBackupInfo) string {
+		return row.InstanceId
+	}),
+}
+
+var InstanceLoadBalancer = &Join[ec2.InstanceLoadBalancerInfo]{
+	Name: "lb",
+	Describe: func(ctx context.Context, opts *Options) ([]ec2.InstanceLoadBalancerInfo, error) {
+		elbClient, err := opts.elbClient()
+		if err != nil {
+			return nil, err
+		}
+		elbv2Client, err := opts.elbv2Client()
+		if err != nil {
+			return nil, err
+		}
+		return describeEc2(ec2.InstanceLister, func(ctx context.Context, l *rate.Limiter, _ ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Reservation, ec2.InstanceLoadBalancerInfo], error) {
+			ids, idmv1, idmv2, err := ec2.FetchDataForInstanceLoadBalancerInfo(ctx, l, elbClient, elbv2Client, region, in.Ids, in.Names)
+			if err != nil {
+				return nil, err
+			}
+			in.Ids = ids
+			return func(ich chan<- ec2.InstanceLoadBalancerInfo, items []types.Reservation) error {
+				return ec2.GetInstanceLoadBalancerInfo(ich, items, idmv1, idmv2, in.Namer)
+			}, nil
+		})(ctx, opts)
+	},
+	Compare: func(a, b ec2.InstanceLoadBalancerInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.InstanceLoadBalancerInfo) string {
+		return row.InstanceId
+	}),
+}
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var networkAclCommand = &Command{
+	Name:        "get-network-acls",
+	Usage:       "List EC2 network ACL info",
+	Description: "List EC2 network ACL info in combination with various resources",
+	Flags:       ec2Flags,
+	Joins: []Joiner{
+		NetworkAcl,
+		NetworkAclEntry,
+		NetworkAclAssociation,
+	},
+}
+
+var NetworkAcl = &Join[ec2.NetworkAclInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.NetworkAclLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkAcl, ec2.NetworkAclInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkAclInfo, items []types.NetworkAcl) error {
+			return ec2.GetNetworkAclInfo(ich, items, region, vpcs, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkAclInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			compareBool(b.IsDefault, a.IsDefault),
+			cmp.Compare(a.NetworkAclName, b.NetworkAclName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.NetworkAclInfo) string {
+		return row.NetworkAclId
+	}),
+}
+
+var NetworkAclEntry = &Join[ec2.NetworkAclEntryInfo]{
+	Name: "rules",
+	Describe: describeEc2(ec2.NetworkAclLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkAcl, ec2.NetworkAclEntryInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkAclEntryInfo, items []types.NetworkAcl) error {
+			return ec2.GetNetworkAclEntryInfo(ich, items, region, vpcs, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkAclEntryInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.NetworkAclName, b.NetworkAclName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.RuleNumber, b.RuleNumber),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.NetworkAclEntryInfo) string {
+		return row.NetworkAclId
+	}),
+}
+
+var NetworkAclAssociation = &Join[ec2.NetworkAclAssociationInfo]{
+	Name: "assoc",
+	Describe: describeEc2(ec2.NetworkAclLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.NetworkAcl, ec2.NetworkAclAssociationInfo], error) {
+		vpcs, sbns, err := ec2.FetchDataForNetworkAclAssociationInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.NetworkAclAssociationInfo, items []types.NetworkAcl) error {
+			return ec2.GetNetworkAclAssociationInfo(ich, items, region, vpcs, sbns, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.NetworkAclAssociationInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.NetworkAclName, b.NetworkAclName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.NetworkAclAssociationInfo) string {
+		return row.NetworkAclId
+	}),
+}
@@ -0,0 +1,118 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var vpcPeeringConnectionCommand = &Command{
+	Name:        "get-vpc-peerings",
+	Usage:       "List EC2 VPC peering connection info",
+	Description: "List EC2 VPC peering connection info in combination with various resources",
+	Flags:       ec2Flags,
+	Joins: []Joiner{
+		VpcPeeringConnection,
+		VpcPeeringConnectionCidr,
+		VpcPeeringConnectionRoute,
+	},
+}
+
+var VpcPeeringConnection = &Join[ec2.VpcPeeringConnectionInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.VpcPeeringConnectionLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.VpcPeeringConnection, ec2.VpcPeeringConnectionInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcPeeringConnectionInfo, items []types.VpcPeeringConnection) error {
+			ec2.GetVpcPeeringConnectionInfo(ich, items, region, vpcs, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcPeeringConnectionInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcPeeringConnectionName, b.VpcPeeringConnectionName),
+			cmp.Compare(a.Status, b.Status),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VpcPeeringConnectionInfo) string {
+		return row.VpcPeeringConnectionId
+	}),
+}
+
+var VpcPeeringConnectionCidr = &Join[ec2.VpcPeeringConnectionCidrInfo]{
+	Name: "cidr",
+	Describe: describeEc2(ec2.VpcPeeringConnectionLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.VpcPeeringConnection, ec2.VpcPeeringConnectionCidrInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcPeeringConnectionCidrInfo, items []types.VpcPeeringConnection) error {
+			ec2.GetVpcPeeringConnectionCidrInfo(ich, items, region, vpcs, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcPeeringConnectionCidrInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcPeeringConnectionName, b.VpcPeeringConnectionName),
+			cmp.Compare(b.Side, a.Side),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.VpcPeeringConnectionCidrInfo) string {
+		return row.VpcPeeringConnectionId
+	}),
+}
+
+var VpcPeeringConnectionRoute = &Join[ec2.VpcPeeringConnectionRouteInfo]{
+	Name: "route",
+	Describe: func(ctx context.Context, opts *Options) ([]ec2.VpcPeeringConnectionRouteInfo, error) {
+		// The route tables of both sides are read after every region is fetched, so that a peering connection
+		// across the queried regions is checked once with the data of each side.
+		var (
+			mu   sync.Mutex
+			data = make(map[string]*ec2.VpcPeeringRouteData)
+		)
+		pcxs, err := describeEc2(ec2.VpcPeeringConnectionLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.VpcPeeringConnection, types.VpcPeeringConnection], error) {
+			d, err := ec2.FetchDataForVpcPeeringConnectionRouteInfo(ctx, l, client, region)
+			if err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			data[region] = d
+			mu.Unlock()
+			return func(ich chan<- types.VpcPeeringConnection, items []types.VpcPeeringConnection) error {
+				for _, item := range items {
+					ich <- item
+				}
+				return nil
+			}, nil
+		})(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return ec2.GetVpcPeeringConnectionRouteInfo(pcxs, data, &ec2.Namer{Tags: opts.NameTags}), nil
+	},
+	Compare: func(a, b ec2.VpcPeeringConnectionRouteInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcPeeringConnectionName, b.VpcPeeringConnectionName),
+			cmp.Compare(b.Side, a.Side),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.PeerCidrBlock, b.PeerCidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+	Tags: ec2Tags(func(row ec2.VpcPeeringConnectionRouteInfo) string {
+		return row.VpcPeeringConnectionId
+	}),
+}
@@ -0,0 +1,47 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+)
+
+var planSubnetsCommand = &Command{
+	Name:        "plan-subnets",
+	Usage:       "Plan EC2 subnet CIDR blocks",
+	Description: "List free CIDR blocks in a VPC not overlapping any of its subnets",
+	Flags: []Flag{
+		FlagRegions,
+		FlagVpc,
+		FlagPrefix,
+		FlagCount,
+		FlagAvailabilityZones,
+		FlagNameTag,
+	},
+	Joins: []Joiner{
+		PlannedSubnet,
+	},
+}
+
+var PlannedSubnet = &Join[ec2.PlannedSubnetInfo]{
+	Name: "default",
+	Describe: func(ctx context.Context, opts *Options) ([]ec2.PlannedSubnetInfo, error) {
+		client, err := opts.ec2Client()
+		if err != nil {
+			return nil, err
+		}
+		in := &ec2.PlanSubnetsInput{
+			VpcId:             opts.Vpc,
+			Prefix:            opts.Prefix,
+			Count:             opts.Count,
+			AvailabilityZones: opts.AvailabilityZones,
+			Namer:             &ec2.Namer{Tags: opts.NameTags},
+		}
+		return ec2.PlanSubnets(ctx, client, opts.regions(), in)
+	},
+	Compare: func(a, b ec2.PlannedSubnetInfo) int {
+		return cmp.Compare(a.Index, b.Index)
+	},
+	MergeFields: []int{0, 1, 2},
+}
@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+)
+
+var reachCommand = &Command{
+	Name:        "reach",
+	Usage:       "Check EC2 network reachability",
+	Description: "Check whether a source can reach a destination through security groups, network ACLs and routes",
+	Flags: []Flag{
+		FlagRegions,
+		FlagSource,
+		FlagDestination,
+		FlagProtocol,
+		FlagPort,
+		FlagNameTag,
+	},
+	Joins: []Joiner{
+		Reachability,
+	},
+}
+
+var Reachability = &Join[ec2.ReachabilityInfo]{
+	Name: "default",
+	Describe: func(ctx context.Context, opts *Options) ([]ec2.ReachabilityInfo, error) {
+		client, err := opts.ec2Client()
+		if err != nil {
+			return nil, err
+		}
+		in := &ec2.ReachInput{
+			Source:      opts.Source,
+			Destination: opts.Destination,
+			Protocol:    opts.Protocol,
+			Port:        opts.Port,
+			Namer:       &ec2.Namer{Tags: opts.NameTags},
+		}
+		return ec2.Reach(ctx, client, opts.regions(), in)
+	},
+	Compare: func(a, b ec2.ReachabilityInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.Step, b.Step),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+}
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var routeTableCommand = &Command{
+	Name:        "get-route-tables",
+	Usage:       "List EC2 route table info",
+	Description: "List EC2 route table info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagExpandPrefixLists}),
+	Joins: []Joiner{
+		RouteTable,
+		RouteTableAssociation,
+		RouteTableAudit,
+	},
+}
+
+var RouteTable = &Join[ec2.RouteTableInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.RouteTableLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.RouteTable, ec2.RouteTableInfo], error) {
+		vpcs, gws, err := ec2.FetchDataForRouteTableInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.RouteTableInfo, items []types.RouteTable) error {
+			return ec2.GetRouteTableInfo(ich, items, region, vpcs, gws, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.RouteTableInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.DestinationType, b.DestinationType),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.Destination, b.Destination),
+			cmp.Compare(a.TargetType, b.TargetType),
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.State, b.State),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.RouteTableInfo) string {
+		return row.RouteTableId
+	}),
+}
+
+var RouteTableAssociation = &Join[ec2.RouteTableAssociationInfo]{
+	Name: "assoc",
+	Describe: describeEc2(ec2.RouteTableLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.RouteTable, ec2.RouteTableAssociationInfo], error) {
+		vpcs, sbns, err := ec2.FetchDataForRouteTableAssociationInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.RouteTableAssociationInfo, items []types.RouteTable) error {
+			return ec2.GetRouteTableAssociationInfo(ich, items, region, vpcs, sbns, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.RouteTableAssociationInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			compareBool(a.Main, b.Main),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.State, b.State),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.RouteTableAssociationInfo) string {
+		return row.RouteTableId
+	}),
+}
+
+var RouteTableAudit = &Join[ec2.RouteTableAuditInfo]{
+	Name: "audit",
+	Describe: describeEc2(ec2.RouteTableLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.RouteTable, ec2.RouteTableAuditInfo], error) {
+		vpcs, gws, err := ec2.FetchDataForRouteTableInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.RouteTableAuditInfo, items []types.RouteTable) error {
+			return ec2.GetRouteTableAuditInfo(ich, items, region, vpcs, gws, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.RouteTableAuditInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.Finding, b.Finding),
+			cmp.Compare(a.Destination, b.Destination),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.RouteTableAuditInfo) string {
+		return row.RouteTableId
+	}),
+}
@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var securityGroupCommand = &Command{
+	Name:        "get-security-groups",
+	Usage:       "List EC2 security group info",
+	Description: "List EC2 security group info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagUnused, FlagAuditRules, FlagExpandPrefixLists}),
+	Joins: []Joiner{
+		SecurityGroup,
+		SecurityGroupPermissions,
+		SecurityGroupUsage,
+		SecurityGroupReference,
+		SecurityGroupAudit,
+		SecurityGroupRedundancy,
+	},
+}
+
+var SecurityGroup = &Join[ec2.SecurityGroupInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupInfo(ich, items, region, vpcs, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.SecurityGroupInfo) string {
+		return row.SecurityGroupId
+	}),
+}
+
+var SecurityGroupPermissions = &Join[ec2.SecurityGroupPermissionsInfo]{
+	Name: "perms",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupPermissionsInfo], error) {
+		vpcs, upls, mpls, err := ec2.FetchDataForSecurityGroupPermissionsInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupPermissionsInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupPermissionsInfo(ich, items, region, vpcs, upls, mpls, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupPermissionsInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.PrefixList, b.PrefixList),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.SecurityGroupPermissionsInfo) string {
+		return row.SecurityGroupId
+	}),
+}
+
+var SecurityGroupUsage = &Join[ec2.SecurityGroupUsageInfo]{
+	Name: "usage",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupUsageInfo], error) {
+		vpcs, enis, instances, err := ec2.FetchDataForSecurityGroupUsageInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupUsageInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupUsageInfo(ich, items, region, vpcs, enis, instances, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupUsageInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.Owner, b.Owner),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.NetworkInterfaceName, b.NetworkInterfaceName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.SecurityGroupUsageInfo) string {
+		return row.SecurityGroupId
+	}),
+}
+
+var SecurityGroupReference = &Join[ec2.SecurityGroupReferenceInfo]{
+	Name: "referenced-by",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupReferenceInfo], error) {
+		vpcs, refs, err := ec2.FetchDataForSecurityGroupReferenceInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupReferenceInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupReferenceInfo(ich, items, region, vpcs, refs, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupReferenceInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.ReferencingGroupName, b.ReferencingGroupName),
+			cmp.Compare(a.MissingGroupId, b.MissingGroupId),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.SecurityGroupReferenceInfo) string {
+		return row.SecurityGroupId
+	}),
+}
+
+var SecurityGroupAudit = &Join[ec2.SecurityGroupAuditInfo]{
+	Name: "audit",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupAuditInfo], error) {
+		vpcs, upls, mpls, err := ec2.FetchDataForSecurityGroupPermissionsInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupAuditInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupAuditInfo(ich, items, region, vpcs, upls, mpls, ples, in.AuditRules, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupAuditInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			ec2.CompareSeverity(a.Severity, b.Severity),
+			cmp.Compare(a.Finding, b.Finding),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.SecurityGroupAuditInfo) string {
+		return row.SecurityGroupId
+	}),
+}
+
+var SecurityGroupRedundancy = &Join[ec2.SecurityGroupRedundancyInfo]{
+	Name: "redundant",
+	Describe: describeEc2(ec2.SecurityGroupLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.SecurityGroup, ec2.SecurityGroupRedundancyInfo], error) {
+		vpcs, upls, mpls, err := ec2.FetchDataForSecurityGroupPermissionsInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SecurityGroupRedundancyInfo, items []types.SecurityGroup) error {
+			return ec2.GetSecurityGroupRedundancyInfo(ich, items, region, vpcs, upls, mpls, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SecurityGroupRedundancyInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SecurityGroupName, b.SecurityGroupName),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.IpProtocol, b.IpProtocol),
+			cmp.Compare(a.FromPort, b.FromPort),
+			cmp.Compare(a.ToPort, b.ToPort),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.SecurityGroupRedundancyInfo) string {
+		return row.SecurityGroupId
+	}),
+}
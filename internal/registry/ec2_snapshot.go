@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var snapshotCommand = &Command{
+	Name:        "get-snapshots",
+	Usage:       "List EC2 snapshot info",
+	Description: "List EC2 snapshot info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagOlderThan}),
+	Joins: []Joiner{
+		Snapshot,
+		SnapshotLineage,
+		SnapshotPermission,
+	},
+}
+
+var Snapshot = &Join[ec2.SnapshotInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.SnapshotLister, func(_ context.Context, _ *rate.Limiter, _ ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Snapshot, ec2.SnapshotInfo], error) {
+		now := time.Now()
+		return func(ich chan<- ec2.SnapshotInfo, items []types.Snapshot) error {
+			ec2.GetSnapshotInfo(ich, items, region, now, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.SnapshotInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VolumeId, b.VolumeId),
+			cmp.Compare(a.StartTime, b.StartTime),
+			cmp.Compare(a.SnapshotName, b.SnapshotName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.SnapshotInfo) string {
+		return row.SnapshotId
+	}),
+}
+
+var SnapshotLineage = &Join[ec2.SnapshotLineageInfo]{
+	Name: "lineage",
+	Describe: describeEc2(ec2.SnapshotLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Snapshot, ec2.SnapshotLineageInfo], error) {
+		vols, imgs, instances, err := ec2.FetchDataForSnapshotLineageInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SnapshotLineageInfo, items []types.Snapshot) error {
+			ec2.GetSnapshotLineageInfo(ich, items, region, vols, imgs, instances, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.SnapshotLineageInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SnapshotName, b.SnapshotName),
+			cmp.Compare(a.ImageName, b.ImageName),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.SnapshotLineageInfo) string {
+		return row.SnapshotId
+	}),
+}
+
+var SnapshotPermission = &Join[ec2.SnapshotPermissionInfo]{
+	Name: "perms",
+	Describe: describeEc2(ec2.SnapshotLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Snapshot, ec2.SnapshotPermissionInfo], error) {
+		return func(ich chan<- ec2.SnapshotPermissionInfo, items []types.Snapshot) error {
+			return ec2.GetSnapshotPermissionInfo(ctx, l, client, ich, items, region, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SnapshotPermissionInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SnapshotName, b.SnapshotName),
+			cmp.Compare(a.Sharing, b.Sharing),
+			cmp.Compare(a.Group, b.Group),
+			cmp.Compare(a.UserId, b.UserId),
+		)
+	},
+	MergeFields: []int{0, 1, 2},
+	Tags: ec2Tags(func(row ec2.SnapshotPermissionInfo) string {
+		return row.SnapshotId
+	}),
+}
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var subnetCommand = &Command{
+	Name:        "get-subnets",
+	Usage:       "List EC2 subnet info",
+	Description: "List EC2 subnet info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagMinUtilization, FlagExpandPrefixLists}),
+	Joins: []Joiner{
+		Subnet,
+		SubnetRoute,
+		SubnetNetworkAcl,
+	},
+}
+
+var Subnet = &Join[ec2.SubnetInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.SubnetLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Subnet, ec2.SubnetInfo], error) {
+		vpcs, err := client.FetchVpcs(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SubnetInfo, items []types.Subnet) error {
+			return ec2.GetSubnetInfo(ich, items, region, vpcs, in.MinUtilization, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SubnetInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.VpcName, b.VpcName),
+			compareBool(a.DefaultForAz, b.DefaultForAz),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6, 7},
+	Tags: ec2Tags(func(row ec2.SubnetInfo) string {
+		return row.SubnetId
+	}),
+}
+
+var SubnetRoute = &Join[ec2.SubnetRouteInfo]{
+	Name: "route",
+	Describe: describeEc2(ec2.SubnetLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Subnet, ec2.SubnetRouteInfo], error) {
+		vpcs, rtbs, gws, err := ec2.FetchDataForSubnetRouteInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		ples, err := fetchPrefixListEntries(ctx, l, client, region, in)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SubnetRouteInfo, items []types.Subnet) error {
+			return ec2.GetSubnetRouteInfo(ich, items, region, vpcs, rtbs, gws, ples, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SubnetRouteInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.DestinationType, b.DestinationType),
+			cmp.Compare(a.TargetType, b.TargetType),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.SubnetRouteInfo) string {
+		return row.SubnetId
+	}),
+}
+
+var SubnetNetworkAcl = &Join[ec2.SubnetNetworkAclInfo]{
+	Name: "nacl",
+	Describe: describeEc2(ec2.SubnetLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Subnet, ec2.SubnetNetworkAclInfo], error) {
+		vpcs, acls, err := ec2.FetchDataForSubnetNetworkAclInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.SubnetNetworkAclInfo, items []types.Subnet) error {
+			return ec2.GetSubnetNetworkAclInfo(ich, items, region, vpcs, acls, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.SubnetNetworkAclInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.NetworkAclName, b.NetworkAclName),
+			cmp.Compare(b.FlowDirection, a.FlowDirection),
+			cmp.Compare(a.RuleNumber, b.RuleNumber),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6, 7},
+	Tags: ec2Tags(func(row ec2.SubnetNetworkAclInfo) string {
+		return row.SubnetId
+	}),
+}
@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var transitGatewayCommand = &Command{
+	Name:        "get-transit-gateways",
+	Usage:       "List EC2 transit gateway info",
+	Description: "List EC2 transit gateway info in combination with various resources",
+	Flags:       ec2Flags,
+	Joins: []Joiner{
+		TransitGateway,
+		TransitGatewayAttachment,
+		TransitGatewayRouteTable,
+		TransitGatewayRoute,
+	},
+}
+
+var TransitGateway = &Join[ec2.TransitGatewayInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.TransitGatewayLister, func(_ context.Context, _ *rate.Limiter, _ ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.TransitGateway, ec2.TransitGatewayInfo], error) {
+		return func(ich chan<- ec2.TransitGatewayInfo, items []types.TransitGateway) error {
+			ec2.GetTransitGatewayInfo(ich, items, region, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.TransitGatewayInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.TransitGatewayName, b.TransitGatewayName),
+			cmp.Compare(a.OwnerId, b.OwnerId),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.TransitGatewayInfo) string {
+		return row.TransitGatewayId
+	}),
+}
+
+var TransitGatewayAttachment = &Join[ec2.TransitGatewayAttachmentInfo]{
+	Name: "attach",
+	Describe: describeEc2(ec2.TransitGatewayLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.TransitGateway, ec2.TransitGatewayAttachmentInfo], error) {
+		res, err := ec2.FetchTransitGatewayResources(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.TransitGatewayAttachmentInfo, items []types.TransitGateway) error {
+			ec2.GetTransitGatewayAttachmentInfo(ich, items, region, res, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.TransitGatewayAttachmentInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.TransitGatewayName, b.TransitGatewayName),
+			cmp.Compare(a.ResourceType, b.ResourceType),
+			cmp.Compare(a.AttachmentName, b.AttachmentName),
+		)
+	},
+	MergeFields: []int{0, 1},
+	Tags: ec2Tags(func(row ec2.TransitGatewayAttachmentInfo) string {
+		return row.TransitGatewayId
+	}),
+}
+
+var TransitGatewayRouteTable = &Join[ec2.TransitGatewayRouteTableInfo]{
+	Name: "rtb",
+	Describe: describeEc2(ec2.TransitGatewayLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.TransitGateway, ec2.TransitGatewayRouteTableInfo], error) {
+		res, err := ec2.FetchTransitGatewayResources(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.TransitGatewayRouteTableInfo, items []types.TransitGateway) error {
+			return ec2.GetTransitGatewayRouteTableInfo(ctx, l, client, ich, items, region, res, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.TransitGatewayRouteTableInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.TransitGatewayName, b.TransitGatewayName),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.BindingType, b.BindingType),
+			cmp.Compare(a.ResourceType, b.ResourceType),
+			cmp.Compare(a.AttachmentName, b.AttachmentName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6},
+	Tags: ec2Tags(func(row ec2.TransitGatewayRouteTableInfo) string {
+		return row.TransitGatewayId
+	}),
+}
+
+var TransitGatewayRoute = &Join[ec2.TransitGatewayRouteInfo]{
+	Name: "route",
+	Describe: describeEc2(ec2.TransitGatewayLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.TransitGateway, ec2.TransitGatewayRouteInfo], error) {
+		res, err := ec2.FetchTransitGatewayResources(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.TransitGatewayRouteInfo, items []types.TransitGateway) error {
+			return ec2.GetTransitGatewayRouteInfo(ctx, l, client, ich, items, region, res, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.TransitGatewayRouteInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.TransitGatewayName, b.TransitGatewayName),
+			cmp.Compare(a.RouteTableName, b.RouteTableName),
+			cmp.Compare(a.DestinationType, b.DestinationType),
+			cmp.Compare(a.Destination, b.Destination),
+			cmp.Compare(a.AttachmentName, b.AttachmentName),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3},
+	Tags: ec2Tags(func(row ec2.TransitGatewayRouteInfo) string {
+		return row.TransitGatewayId
+	}),
+}
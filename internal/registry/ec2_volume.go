@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var volumeCommand = &Command{
+	Name:        "get-volumes",
+	Usage:       "List EC2 volume info",
+	Description: "List EC2 volume info in combination with various resources",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagUnattached}),
+	Joins: []Joiner{
+		Volume,
+		VolumeSnapshot,
+	},
+}
+
+var Volume = &Join[ec2.VolumeInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.VolumeLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Volume, ec2.VolumeInfo], error) {
+		instances, err := client.FetchInstances(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VolumeInfo, items []types.Volume) error {
+			ec2.GetVolumeInfo(ich, items, region, instances, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VolumeInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.State, b.State),
+			cmp.Compare(a.InstanceName, b.InstanceName),
+			cmp.Compare(a.DeviceName, b.DeviceName),
+			cmp.Compare(a.VolumeName, b.VolumeName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VolumeInfo) string {
+		return row.VolumeId
+	}),
+}
+
+var VolumeSnapshot = &Join[ec2.VolumeSnapshotInfo]{
+	Name: "snapshot",
+	Describe: describeEc2(ec2.VolumeLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Volume, ec2.VolumeSnapshotInfo], error) {
+		snps, err := client.FetchSnapshots(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		return func(ich chan<- ec2.VolumeSnapshotInfo, items []types.Volume) error {
+			ec2.GetVolumeSnapshotInfo(ich, items, region, snps, now, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VolumeSnapshotInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.VolumeName, b.VolumeName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VolumeSnapshotInfo) string {
+		return row.VolumeId
+	}),
+}
@@ -0,0 +1,225 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"golang.org/x/time/rate"
+)
+
+var vpcCommand = &Command{
+	Name:        "get-vpcs",
+	Usage:       "List EC2 VPC info",
+	Description: "List EC2 VPC info in combination with various resources; the overlap join covers the VPCs of a single account and the ones shared with it",
+	Flags:       slices.Concat(ec2Flags, []Flag{FlagMinUtilization}),
+	Joins: []Joiner{
+		Vpc,
+		VpcAttribute,
+		VpcCidr,
+		VpcGateway,
+		VpcCapacity,
+		VpcCidrOverlap,
+	},
+}
+
+var Vpc = &Join[ec2.VpcInfo]{
+	Name: "default",
+	Describe: describeEc2(ec2.VpcLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcInfo], error) {
+		dopts, err := client.FetchDhcpOptions(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcInfo, items []types.Vpc) error {
+			return ec2.GetVpcInfo(ich, items, region, dopts, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.DhcpOptionsName, b.DhcpOptionsName),
+			cmp.Compare(a.OwnerId, b.OwnerId),
+			compareBool(b.IsDefault, a.IsDefault),
+			cmp.Compare(a.InstanceTenancy, b.InstanceTenancy),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VpcInfo) string {
+		return row.VpcId
+	}),
+}
+
+var VpcAttribute = &Join[ec2.VpcAttributeInfo]{
+	Name: "attr",
+	Describe: describeEc2(ec2.VpcLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcAttributeInfo], error) {
+		dopts, err := client.FetchDhcpOptions(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcAttributeInfo, items []types.Vpc) error {
+			return ec2.GetVpcAttributeInfo(ctx, l, client, ich, items, region, dopts, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcAttributeInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.DhcpOptionsName, b.DhcpOptionsName),
+			cmp.Compare(a.OwnerId, b.OwnerId),
+			compareBool(b.IsDefault, a.IsDefault),
+			cmp.Compare(a.InstanceTenancy, b.InstanceTenancy),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VpcAttributeInfo) string {
+		return row.VpcId
+	}),
+}
+
+var VpcCidr = &Join[ec2.VpcCidrInfo]{
+	Name: "cidr",
+	Describe: describeEc2(ec2.VpcLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcCidrInfo], error) {
+		dopts, err := client.FetchDhcpOptions(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcCidrInfo, items []types.Vpc) error {
+			return ec2.GetVpcCidrInfo(ich, items, region, dopts, in.Namer)
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcCidrInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.DhcpOptionsName, b.DhcpOptionsName),
+			cmp.Compare(a.OwnerId, b.OwnerId),
+			compareBool(b.IsDefault, a.IsDefault),
+			cmp.Compare(a.InstanceTenancy, b.InstanceTenancy),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+			cmp.Compare(a.State, b.State),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5, 6, 7},
+	Tags: ec2Tags(func(row ec2.VpcCidrInfo) string {
+		return row.VpcId
+	}),
+}
+
+var VpcGateway = &Join[ec2.VpcGatewayInfo]{
+	Name: "gateways",
+	Describe: describeEc2(ec2.VpcLister, func(ctx context.Context, l *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcGatewayInfo], error) {
+		sbns, gws, err := ec2.FetchDataForVpcGatewayInfo(ctx, l, client, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcGatewayInfo, items []types.Vpc) error {
+			ec2.GetVpcGatewayInfo(ich, items, region, sbns, gws, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcGatewayInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.GatewayType, b.GatewayType),
+			cmp.Compare(a.GatewayName, b.GatewayName),
+			cmp.Compare(a.AvailabilityZone, b.AvailabilityZone),
+			cmp.Compare(a.SubnetName, b.SubnetName),
+			cmp.Compare(a.PublicIp, b.PublicIp),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4, 5},
+	Tags: ec2Tags(func(row ec2.VpcGatewayInfo) string {
+		return row.VpcId
+	}),
+}
+
+var VpcCapacity = &Join[ec2.VpcCapacityInfo]{
+	Name: "capacity",
+	Describe: describeEc2(ec2.VpcLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcCapacityInfo], error) {
+		sbns, err := client.FetchSubnets(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		return func(ich chan<- ec2.VpcCapacityInfo, items []types.Vpc) error {
+			ec2.GetVpcCapacityInfo(ich, items, region, sbns, in.MinUtilization, in.Namer)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b ec2.VpcCapacityInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(b.Utilization, a.Utilization),
+			cmp.Compare(a.VpcName, b.VpcName),
+		)
+	},
+	Tags: ec2Tags(func(row ec2.VpcCapacityInfo) string {
+		return row.VpcId
+	}),
+}
+
+var VpcCidrOverlap = &Join[ec2.VpcCidrOverlapInfo]{
+	Name: "overlap",
+	Describe: func(ctx context.Context, opts *Options) ([]ec2.VpcCidrOverlapInfo, error) {
+		// Every VPC of the regions is listed once and compared with the ones selected by ids, names, filters and tags.
+		selection := &ec2.Input{
+			Ids:     opts.Ids,
+			Names:   opts.Names,
+			Filters: slices.Concat(opts.Filters, tagFilters(opts.Tags)),
+			Namer:   &ec2.Namer{Tags: opts.NameTags},
+		}
+		if err := ec2.VpcLister.Validate(selection); err != nil {
+			return nil, err
+		}
+		selects := len(selection.Ids) > 0 || len(selection.Names) > 0 || len(selection.Filters) > 0
+		var (
+			mu     sync.Mutex
+			vpcIds []string
+		)
+		all := *opts
+		all.Ids, all.Names, all.Filters, all.Tags = nil, nil, nil, nil
+		cidrs, err := describeEc2(ec2.VpcLister, func(ctx context.Context, _ *rate.Limiter, client ec2.IEc2Client, region string, in *ec2.Input) (ec2.Builder[types.Vpc, ec2.VpcCidrInfo], error) {
+			dopts, err := client.FetchDhcpOptions(ctx, region)
+			if err != nil {
+				return nil, err
+			}
+			return func(ich chan<- ec2.VpcCidrInfo, items []types.Vpc) error {
+				if selects {
+					ids := ec2.SelectVpcIds(items, selection)
+					mu.Lock()
+					vpcIds = append(vpcIds, ids...)
+					mu.Unlock()
+				}
+				return ec2.GetVpcCidrInfo(ich, items, region, dopts, in.Namer)
+			}, nil
+		})(ctx, &all)
+		if err != nil {
+			return nil, err
+		}
+		if !selects {
+			return ec2.GetVpcCidrOverlapInfo(cidrs, nil), nil
+		}
+		if len(vpcIds) == 0 {
+			return nil, nil
+		}
+		return ec2.GetVpcCidrOverlapInfo(cidrs, vpcIds), nil
+	},
+	Compare: func(a, b ec2.VpcCidrOverlapInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.VpcName, b.VpcName),
+			cmp.Compare(a.AddressType, b.AddressType),
+			cmp.Compare(a.CidrBlock, b.CidrBlock),
+			cmp.Compare(a.PeerRegion, b.PeerRegion),
+			cmp.Compare(a.PeerVpcName, b.PeerVpcName),
+			cmp.Compare(a.PeerCidrBlock, b.PeerCidrBlock),
+		)
+	},
+	MergeFields: []int{0, 1, 2, 3, 4},
+	Tags: ec2Tags(func(row ec2.VpcCidrOverlapInfo) string {
+		return row.VpcId
+	}),
+}
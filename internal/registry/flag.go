@@ -0,0 +1,73 @@
+package registry
+
+// Flag is a command line flag a command accepts besides the common ones.
+type Flag int
+
+const (
+	FlagRegions Flag = iota
+	FlagIds
+	FlagNames
+	FlagFilter
+	FlagFilterFile
+	FlagFilterVar
+	FlagDefaultFilter
+	FlagDocument
+	FlagDocumentFilter
+	FlagScope
+	FlagTag
+	FlagShowTags
+	FlagNameTag
+	FlagUnattached
+	FlagOlderThan
+	FlagUnassociated
+	FlagUnused
+	FlagAuditRules
+	FlagExpandPrefixLists
+	FlagSource
+	FlagDestination
+	FlagProtocol
+	FlagPort
+	FlagMinUtilization
+	FlagVpc
+	FlagPrefix
+	FlagCount
+	FlagAvailabilityZones
+)
+
+var flags = []string{
+	"regions",
+	"ids",
+	"names",
+	"filter",
+	"filter-file",
+	"filter-var",
+	"default-filter",
+	"document",
+	"document-filter",
+	"scope",
+	"tag",
+	"show-tags",
+	"name-tag",
+	"unattached",
+	"older-than",
+	"unassociated",
+	"unused",
+	"audit-rules",
+	"expand-prefix-lists",
+	"source",
+	"destination",
+	"protocol",
+	"port",
+	"min-utilization",
+	"vpc",
+	"prefix",
+	"count",
+	"availability-zones",
+}
+
+func (f Flag) String() string {
+	if f >= 0 && int(f) < len(flags) {
+		return flags[f]
+	}
+	return ""
+}
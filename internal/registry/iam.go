@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+)
+
+var iamService = &Service{
+	Name:        "iam",
+	Usage:       "Invoke IAM API and list resources",
+	Description: "Invoke IAM API and list resources in various output formats",
+	Commands: []*Command{
+		userCommand,
+		groupCommand,
+		roleCommand,
+		policyCommand,
+	},
+}
+
+var iamFlags = []Flag{
+	FlagIds,
+	FlagNames,
+	FlagDocument,
+	FlagDocumentFilter,
+}
+
+func listIam[I, T any](list iam.Lister[I], handle iam.Handler[I, T]) func(ctx context.Context, opts *Options) ([]T, error) {
+	return func(ctx context.Context, opts *Options) ([]T, error) {
+		client, err := opts.iamClient()
+		if err != nil {
+			return nil, err
+		}
+		in := &iam.Input{
+			Ids:             opts.Ids,
+			Names:           opts.Names,
+			Scope:           opts.scope(),
+			Document:        opts.Document,
+			DocumentFilters: opts.DocumentFilters,
+		}
+		return iam.List(ctx, client, in, list, handle)
+	}
+}
@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"golang.org/x/time/rate"
+)
+
+var groupCommand = &Command{
+	Name:        "get-groups",
+	Usage:       "List IAM group info",
+	Description: "List IAM group info in combination with related info",
+	Flags:       iamFlags,
+	Joins: []Joiner{
+		Group,
+		GroupPolicy,
+	},
+}
+
+var Group = &Join[iam.GroupInfo]{
+	Name: "default",
+	Describe: listIam(iam.ListGroups, func(_ context.Context, _ iam.IIamClient, _ *iam.Input) (iam.Builder[types.Group, iam.GroupInfo], error) {
+		return func(_ context.Context, _ *rate.Limiter, ich chan<- iam.GroupInfo, item types.Group) error {
+			iam.GetGroupInfo(ich, item)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b iam.GroupInfo) int {
+		return cmp.Compare(a.GroupName, b.GroupName)
+	},
+}
+
+var GroupPolicy = &Join[iam.GroupPolicyInfo]{
+	Name: "policy",
+	Describe: listIam(iam.ListGroups, func(ctx context.Context, client iam.IIamClient, in *iam.Input) (iam.Builder[types.Group, iam.GroupPolicyInfo], error) {
+		pols, err := client.FetchCustomerPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, l *rate.Limiter, ich chan<- iam.GroupPolicyInfo, item types.Group) error {
+			return iam.GetGroupPolicyInfo(ctx, l, client, ich, item, in.Document, in.DocumentFilters, pols)
+		}, nil
+	}),
+	Compare: func(a, b iam.GroupPolicyInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.GroupName, b.GroupName),
+			cmp.Compare(a.PolicyType, b.PolicyType),
+		)
+	},
+	MergeFields:    []int{0, 1},
+	DocumentFields: []int{5},
+}
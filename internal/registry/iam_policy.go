@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"golang.org/x/time/rate"
+)
+
+var policyCommand = &Command{
+	Name:        "get-policies",
+	Usage:       "List IAM policy info",
+	Description: "List IAM policy info in combination with related info",
+	Flags: []Flag{
+		FlagIds,
+		FlagNames,
+		FlagDocument,
+		FlagDocumentFilter,
+		FlagScope,
+	},
+	Joins: []Joiner{
+		Policy,
+	},
+}
+
+var Policy = &Join[iam.PolicyInfo]{
+	Name: "default",
+	Describe: listIam(iam.ListPolicies, func(_ context.Context, client iam.IIamClient, in *iam.Input) (iam.Builder[types.Policy, iam.PolicyInfo], error) {
+		return func(ctx context.Context, _ *rate.Limiter, ich chan<- iam.PolicyInfo, item types.Policy) error {
+			return iam.GetPolicyInfo(ctx, client, ich, item, in.Document, in.DocumentFilters)
+		}, nil
+	}),
+	Compare: func(a, b iam.PolicyInfo) int {
+		return cmp.Compare(a.PolicyName, b.PolicyName)
+	},
+	MergeFields:    []int{0, 1},
+	DocumentFields: []int{8},
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"golang.org/x/time/rate"
+)
+
+var roleCommand = &Command{
+	Name:        "get-roles",
+	Usage:       "List IAM role info",
+	Description: "List IAM role info in combination with related info",
+	Flags: []Flag{
+		FlagIds,
+		FlagNames,
+		FlagDocument,
+		FlagDocumentFilter,
+		FlagTag,
+		FlagShowTags,
+	},
+	Joins: []Joiner{
+		Role,
+		RolePolicy,
+		RoleAssume,
+	},
+}
+
+var Role = &Join[iam.RoleInfo]{
+	Name: "default",
+	Describe: listIam(iam.ListRoles, func(_ context.Context, _ iam.IIamClient, _ *iam.Input) (iam.Builder[types.Role, iam.RoleInfo], error) {
+		return func(_ context.Context, _ *rate.Limiter, ich chan<- iam.RoleInfo, item types.Role) error {
+			iam.GetRoleInfo(ich, item)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b iam.RoleInfo) int {
+		return cmp.Compare(a.RoleName, b.RoleName)
+	},
+	Tags: iamRoleTags(func(row iam.RoleInfo) string {
+		return row.RoleName
+	}),
+}
+
+var RolePolicy = &Join[iam.RolePolicyInfo]{
+	Name: "policy",
+	Describe: listIam(iam.ListRoles, func(ctx context.Context, client iam.IIamClient, in *iam.Input) (iam.Builder[types.Role, iam.RolePolicyInfo], error) {
+		pols, err := client.FetchCustomerPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, l *rate.Limiter, ich chan<- iam.RolePolicyInfo, item types.Role) error {
+			return iam.GetRolePolicyInfo(ctx, l, client, ich, item, in.Document, in.DocumentFilters, pols)
+		}, nil
+	}),
+	Compare: func(a, b iam.RolePolicyInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.RoleName, b.RoleName),
+			cmp.Compare(a.PolicyType, b.PolicyType),
+		)
+	},
+	MergeFields:    []int{0, 1},
+	DocumentFields: []int{5},
+	Tags: iamRoleTags(func(row iam.RolePolicyInfo) string {
+		return row.RoleName
+	}),
+}
+
+var RoleAssume = &Join[iam.RoleAssumeInfo]{
+	Name: "assume",
+	Describe: listIam(iam.ListRoles, func(_ context.Context, _ iam.IIamClient, _ *iam.Input) (iam.Builder[types.Role, iam.RoleAssumeInfo], error) {
+		return func(_ context.Context, _ *rate.Limiter, ich chan<- iam.RoleAssumeInfo, item types.Role) error {
+			return iam.GetRoleAssumeInfo(ich, item)
+		}, nil
+	}),
+	Compare: func(a, b iam.RoleAssumeInfo) int {
+		return cmp.Compare(a.RoleName, b.RoleName)
+	},
+	Tags: iamRoleTags(func(row iam.RoleAssumeInfo) string {
+		return row.RoleName
+	}),
+}
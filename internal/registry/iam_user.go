@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"golang.org/x/time/rate"
+)
+
+var userCommand = &Command{
+	Name:        "get-users",
+	Usage:       "List IAM user info",
+	Description: "List IAM user info in combination with related info",
+	Flags: []Flag{
+		FlagIds,
+		FlagNames,
+		FlagDocument,
+		FlagDocumentFilter,
+		FlagTag,
+		FlagShowTags,
+	},
+	Joins: []Joiner{
+		User,
+		UserPolicy,
+		UserGroup,
+		UserAssociation,
+	},
+}
+
+var User = &Join[iam.UserInfo]{
+	Name: "default",
+	Describe: listIam(iam.ListUsers, func(_ context.Context, _ iam.IIamClient, _ *iam.Input) (iam.Builder[types.User, iam.UserInfo], error) {
+		return func(_ context.Context, _ *rate.Limiter, ich chan<- iam.UserInfo, item types.User) error {
+			iam.GetUserInfo(ich, item)
+			return nil
+		}, nil
+	}),
+	Compare: func(a, b iam.UserInfo) int {
+		return cmp.Compare(a.UserName, b.UserName)
+	},
+	Tags: iamUserTags(func(row iam.UserInfo) string {
+		return row.UserName
+	}),
+}
+
+var UserPolicy = &Join[iam.UserPolicyInfo]{
+	Name: "policy",
+	Describe: listIam(iam.ListUsers, func(ctx context.Context, client iam.IIamClient, in *iam.Input) (iam.Builder[types.User, iam.UserPolicyInfo], error) {
+		pols, err := client.FetchCustomerPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, l *rate.Limiter, ich chan<- iam.UserPolicyInfo, item types.User) error {
+			return iam.GetUserPolicyInfo(ctx, l, client, ich, item, in.Document, in.DocumentFilters, pols)
+		}, nil
+	}),
+	Compare: func(a, b iam.UserPolicyInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.UserName, b.UserName),
+			cmp.Compare(a.PolicyType, b.PolicyType),
+		)
+	},
+	MergeFields:    []int{0, 1},
+	DocumentFields: []int{5},
+	Tags: iamUserTags(func(row iam.UserPolicyInfo) string {
+		return row.UserName
+	}),
+}
+
+var UserGroup = &Join[iam.UserGroupInfo]{
+	Name: "group",
+	Describe: listIam(iam.ListUsers, func(_ context.Context, client iam.IIamClient, _ *iam.Input) (iam.Builder[types.User, iam.UserGroupInfo], error) {
+		return func(ctx context.Context, _ *rate.Limiter, ich chan<- iam.UserGroupInfo, item types.User) error {
+			return iam.GetUserGroupInfo(ctx, client, ich, item)
+		}, nil
+	}),
+	Compare: func(a, b iam.UserGroupInfo) int {
+		return cmp.Compare(a.UserName, b.UserName)
+	},
+	MergeFields: []int{0, 1},
+	Tags: iamUserTags(func(row iam.UserGroupInfo) string {
+		return row.UserName
+	}),
+}
+
+var UserAssociation = &Join[iam.UserAssociationInfo]{
+	Name: "assoc",
+	Describe: listIam(iam.ListUsers, func(ctx context.Context, client iam.IIamClient, in *iam.Input) (iam.Builder[types.User, iam.UserAssociationInfo], error) {
+		pols, err := client.FetchCustomerPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, l *rate.Limiter, ich chan<- iam.UserAssociationInfo, item types.User) error {
+			return iam.GetUserAssociationInfo(ctx, l, client, ich, item, in.Document, in.DocumentFilters, pols)
+		}, nil
+	}),
+	Compare: func(a, b iam.UserAssociationInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.UserName, b.UserName),
+			cmp.Compare(b.AttachedBy, a.AttachedBy),
+			cmp.Compare(a.PolicyType, b.PolicyType),
+		)
+	},
+	MergeFields:    []int{0, 1},
+	DocumentFields: []int{4},
+	Tags: iamUserTags(func(row iam.UserAssociationInfo) string {
+		return row.UserName
+	}),
+}
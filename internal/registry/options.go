@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"github.com/nekrassov01/aws-describer/internal/api/elb"
+	"github.com/nekrassov01/aws-describer/internal/api/elbv2"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"github.com/nekrassov01/aws-describer/internal/api/s3"
+)
+
+// Options configures a request. Fields that do not apply to a join are ignored.
+type Options struct {
+	// Regions to request for EC2 resources. All default target regions are requested when empty.
+	Regions []string
+
+	// Ids and Names narrow the result to the given resource ids and names.
+	// EC2 resources named by tags are matched against the first of NameTags present.
+	Ids   []string
+	Names []string
+
+	// Filters are passed to the EC2 describe API as is.
+	Filters []types.Filter
+
+	// Tags narrow the result to resources having every tag key with any of its values,
+	// or having the key at all when no value is given.
+	Tags map[string][]string
+
+	// ShowTags are the tag keys printed as extra columns by Print.
+	ShowTags []string
+
+	// NameTags are the case-sensitive tag keys EC2 resource names are read from, in order of precedence.
+	// Resources without any of them are named by an attribute such as the DNS name, or by id.
+	// Defaults to "Name".
+	NameTags []string
+
+	// DefaultFilter applies the resource specific default EC2 filter,
+	// such as excluding terminated instances or default VPCs.
+	DefaultFilter bool
+
+	// Unattached narrows EC2 volumes to the ones not attached to any instance.
+	Unattached bool
+
+	// OlderThan narrows EC2 snapshots to the ones started longer ago than the duration.
+	OlderThan time.Duration
+
+	// Unassociated narrows EC2 elastic IP addresses to the ones not associated with any resource.
+	Unassociated bool
+
+	// Unused narrows EC2 security groups to the ones not attached to any network interface, except default ones.
+	Unused bool
+
+	// AuditRules configures the audit of EC2 security group rules. ec2.DefaultAuditRules is applied when nil.
+	AuditRules *ec2.AuditRules
+
+	// ExpandPrefixLists expands EC2 security group rules and routes referencing a prefix list to a row per CIDR block.
+	// The redundancy joins compare prefix lists as written.
+	ExpandPrefixLists bool
+
+	// MinUtilization narrows EC2 subnets and the VPC capacity to the ones using at least the percentage of their IPv4 addresses.
+	MinUtilization float64
+
+	// Source and Destination are the ends of the flow checked for EC2 reachability:
+	// an instance id, a network interface id, an IP address or a CIDR block.
+	// Protocol defaults to tcp, and Port is required for tcp and udp.
+	Source      string
+	Destination string
+	Protocol    string
+	Port        int32
+
+	// Vpc is the VPC to plan EC2 subnets in: Count blocks with the Prefix length, 1 by default,
+	// assigned to the AvailabilityZones in turn.
+	Vpc               string
+	Prefix            int
+	Count             int
+	AvailabilityZones []string
+
+	// Document enables output of IAM and S3 policy documents.
+	// DocumentFilters keeps only policies whose document contains any of the words.
+	Document        bool
+	DocumentFilters []string
+
+	// Scope is the IAM policy scope: "local" (default) or "aws".
+	Scope string
+
+	// Config is used to create any client that is not set explicitly.
+	Config *aws.Config
+
+	Ec2Client   ec2.IEc2Client
+	ElbClient   elb.IElbClient
+	Elbv2Client elbv2.IElbClient
+	IamClient   iam.IIamClient
+	S3Client    s3.IS3Client
+}
+
+func options(opts *Options) *Options {
+	if opts == nil {
+		return &Options{}
+	}
+	return opts
+}
+
+func (o *Options) regions() []string {
+	if len(o.Regions) == 0 {
+		return api.DefaultTargetRegions
+	}
+	return o.Regions
+}
+
+func (o *Options) scope() string {
+	if o.Scope == "" {
+		return iam.PolicyScopeTypeLocal.String()
+	}
+	return o.Scope
+}
+
+func (o *Options) validate() error {
+	if !o.Document && len(o.DocumentFilters) > 0 {
+		return fmt.Errorf("invalid options: document filters are valid only when document is enabled")
+	}
+	for _, k := range o.tagKeys() {
+		if k == "" {
+			return fmt.Errorf("invalid options: tag keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// tagKeys returns the tag keys to look up for selecting and showing tags.
+func (o *Options) tagKeys() []string {
+	keys := slices.Clone(o.ShowTags)
+	for k := range o.Tags {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+func (o *Options) config() (*aws.Config, error) {
+	if o.Config == nil {
+		return nil, fmt.Errorf("invalid options: config is required unless all clients are set")
+	}
+	return o.Config, nil
+}
+
+func (o *Options) ec2Client() (ec2.IEc2Client, error) {
+	if o.Ec2Client != nil {
+		return o.Ec2Client, nil
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return ec2.NewEc2Client(cfg), nil
+}
+
+func (o *Options) elbClient() (elb.IElbClient, error) {
+	if o.ElbClient != nil {
+		return o.ElbClient, nil
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return elb.NewElbClient(cfg), nil
+}
+
+func (o *Options) elbv2Client() (elbv2.IElbClient, error) {
+	if o.Elbv2Client != nil {
+		return o.Elbv2Client, nil
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return elbv2.NewElbClient(cfg), nil
+}
+
+func (o *Options) iamClient() (iam.IIamClient, error) {
+	if o.IamClient != nil {
+		return o.IamClient, nil
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return iam.NewIamClient(cfg), nil
+}
+
+func (o *Options) s3Client() (s3.IS3Client, error) {
+	if o.S3Client != nil {
+		return o.S3Client, nil
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return nil, err
+	}
+	return s3.NewS3Client(cfg), nil
+}
@@ -0,0 +1,205 @@
+// Package registry declares every resource the describer can list.
+//
+// A service groups commands such as "ec2 get-instances", and a command groups
+// joins such as "--join sg". Each join is declared once with its describe
+// function, default sort order and table layout; the CLI and the public
+// library are derived from these declarations.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nekrassov01/aws-describer/internal/tab"
+)
+
+// Service groups the commands of an AWS service.
+type Service struct {
+	Name        string
+	Usage       string
+	Description string
+	Commands    []*Command
+}
+
+// Command lists a resource joined with related resources. The first join is the default.
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	Flags       []Flag
+	Joins       []Joiner
+}
+
+// Joiner is a join with its row type erased.
+type Joiner interface {
+	JoinName() string
+	HasDocument() bool
+	DefaultMergeFields() []int
+	Columns() []Column
+	Print(ctx context.Context, opts *Options, output string, header bool, mergeFields, ignoreFields []int) error
+}
+
+// Join declares how the rows of a join are described, sorted and printed.
+type Join[T any] struct {
+	// Name is the value selecting the join at --join.
+	Name string
+
+	// Describe returns the rows in no particular order.
+	Describe func(ctx context.Context, opts *Options) ([]T, error)
+
+	// Compare sets the default order of the rows.
+	Compare func(a, b T) int
+
+	// MergeFields are the columns merged by value unless set explicitly.
+	MergeFields []int
+
+	// DocumentFields are the columns printed only when documents are enabled.
+	DocumentFields []int
+
+	// Tags looks up the tags of the resource each row belongs to. Joins without it
+	// do not support selecting rows by tag or printing tag columns.
+	Tags Tagger[T]
+}
+
+var services = []*Service{
+	ec2Service,
+	iamService,
+	s3Service,
+}
+
+// Services returns every service in the order shown in the CLI.
+func Services() []*Service {
+	return services
+}
+
+// Find returns the join selected by name, or the default join if name is empty.
+func (c *Command) Find(name string) (Joiner, error) {
+	if name == "" {
+		return c.Joins[0], nil
+	}
+	for _, j := range c.Joins {
+		if j.JoinName() == name {
+			return j, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid value: %s: valid values: %s", name, strings.Join(c.JoinNames(), "|"))
+}
+
+// JoinNames returns the names of the joins of the command.
+func (c *Command) JoinNames() []string {
+	names := make([]string, 0, len(c.Joins))
+	for _, j := range c.Joins {
+		names = append(names, j.JoinName())
+	}
+	return names
+}
+
+// DocumentJoinNames returns the names of the joins that output documents.
+func (c *Command) DocumentJoinNames() []string {
+	var names []string
+	for _, j := range c.Joins {
+		if j.HasDocument() {
+			names = append(names, j.JoinName())
+		}
+	}
+	return names
+}
+
+// HasFlag reports whether the command accepts the flag.
+func (c *Command) HasFlag(f Flag) bool {
+	return slices.Contains(c.Flags, f)
+}
+
+func (j *Join[T]) JoinName() string {
+	return j.Name
+}
+
+func (j *Join[T]) HasDocument() bool {
+	return len(j.DocumentFields) > 0
+}
+
+func (j *Join[T]) DefaultMergeFields() []int {
+	return j.MergeFields
+}
+
+// Rows returns the rows in the default order, narrowed to the selected tags.
+func (j *Join[T]) Rows(ctx context.Context, opts *Options) ([]T, error) {
+	info, _, err := j.rows(ctx, opts)
+	return info, err
+}
+
+// Print outputs the rows as a table. Merged columns default to the ones of the join,
+// document columns are excluded unless documents are enabled, and a column is appended
+// per tag key to show.
+func (j *Join[T]) Print(ctx context.Context, opts *Options, output string, header bool, mergeFields, ignoreFields []int) error {
+	opts = options(opts)
+	info, tags, err := j.rows(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(mergeFields) == 0 {
+		mergeFields = j.MergeFields
+	}
+	if !opts.Document {
+		ignoreFields = slices.Concat(ignoreFields, j.DocumentFields)
+	}
+	if len(opts.ShowTags) == 0 {
+		return tab.PrintTable(info, output, header, mergeFields, ignoreFields)
+	}
+	table, err := withTagColumns(info, opts.ShowTags, tags)
+	if err != nil {
+		return err
+	}
+	return tab.PrintTable(table, output, header, mergeFields, ignoreFields)
+}
+
+// rows returns the rows with the tags of their resources when tags are selected or shown.
+func (j *Join[T]) rows(ctx context.Context, opts *Options) ([]T, []map[string]string, error) {
+	opts = options(opts)
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+	keys := opts.tagKeys()
+	if len(keys) > 0 && j.Tags == nil {
+		return nil, nil, fmt.Errorf("invalid options: tags are not supported by the join: %s", j.Name)
+	}
+	info, err := j.Describe(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if j.Compare != nil {
+		slices.SortStableFunc(info, j.Compare)
+	}
+	if len(keys) == 0 || len(info) == 0 {
+		return info, nil, nil
+	}
+	tags, err := j.Tags(ctx, opts, info, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(opts.Tags) == 0 {
+		return info, tags, nil
+	}
+	var selected []T
+	var selectedTags []map[string]string
+	for i, row := range info {
+		if matchTags(tags[i], opts.Tags) {
+			selected = append(selected, row)
+			selectedTags = append(selectedTags, tags[i])
+		}
+	}
+	return selected, selectedTags, nil
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/s3"
+)
+
+var s3Service = &Service{
+	Name:        "s3",
+	Usage:       "Invoke S3 API and list resources",
+	Description: "Invoke S3 API and list resources in various output formats",
+	Commands: []*Command{
+		bucketCommand,
+	},
+}
+
+var s3Flags = []Flag{
+	FlagRegions,
+	FlagNames,
+	FlagDocument,
+	FlagDocumentFilter,
+	FlagTag,
+	FlagShowTags,
+}
+
+func listS3[I, T any](list s3.Lister[I], handle s3.Handler[I, T]) func(ctx context.Context, opts *Options) ([]T, error) {
+	return func(ctx context.Context, opts *Options) ([]T, error) {
+		client, err := opts.s3Client()
+		if err != nil {
+			return nil, err
+		}
+		in := &s3.Input{
+			Names:           opts.Names,
+			Document:        opts.Document,
+			DocumentFilters: opts.DocumentFilters,
+		}
+		return s3.List(ctx, client, in, list, handle)
+	}
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/nekrassov01/aws-describer/internal/api/s3"
+)
+
+var bucketCommand = &Command{
+	Name:        "get-buckets",
+	Usage:       "List S3 bucket info",
+	Description: "List S3 bucket info in combination with related info",
+	Flags:       s3Flags,
+	Joins: []Joiner{
+		Bucket,
+	},
+}
+
+var Bucket = &Join[s3.BucketInfo]{
+	Name: "default",
+	Describe: listS3(s3.ListBuckets, func(_ context.Context, client s3.IS3Client, in *s3.Input) (s3.Builder[types.Bucket, s3.BucketInfo], error) {
+		return func(ctx context.Context, ich chan<- s3.BucketInfo, item types.Bucket) error {
+			return s3.GetBucketInfo(ctx, client, ich, item, in.Document, in.DocumentFilters)
+		}, nil
+	}),
+	Compare: func(a, b s3.BucketInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.BucketName, b.BucketName),
+			cmp.Compare(a.Location, b.Location),
+		)
+	},
+	DocumentFields: []int{3},
+	Tags: s3BucketTags(func(row s3.BucketInfo) string {
+		return row.BucketName
+	}, func(row s3.BucketInfo) string {
+		return row.Location
+	}),
+}
@@ -0,0 +1,162 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ServiceSchema describes the commands of a service.
+type ServiceSchema struct {
+	Service  string          `json:"service"`
+	Commands []CommandSchema `json:"commands"`
+}
+
+// CommandSchema describes the joins of a command.
+type CommandSchema struct {
+	Command string       `json:"command"`
+	Joins   []JoinSchema `json:"joins"`
+}
+
+// JoinSchema describes the columns of a join.
+type JoinSchema struct {
+	Join        string   `json:"join"`
+	Default     bool     `json:"default"`
+	MergeFields []int    `json:"mergeFields"`
+	Columns     []Column `json:"columns"`
+}
+
+// Column is a column of a join, as accepted by --merge and --ignore.
+type Column struct {
+	Index    int    `json:"index"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Document bool   `json:"document"`
+}
+
+// SchemaInfo is a column of a join flattened for table output.
+type SchemaInfo struct {
+	Service  string
+	Command  string
+	Join     string
+	Index    int
+	Column   string
+	Type     string
+	Document bool
+}
+
+// Columns returns the columns of the rows in output order.
+func (j *Join[T]) Columns() []Column {
+	t := reflect.TypeFor[T]()
+	var cols []Column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		index := len(cols)
+		cols = append(cols, Column{
+			Index:    index,
+			Name:     f.Name,
+			Type:     f.Type.String(),
+			Document: slices.Contains(j.DocumentFields, index),
+		})
+	}
+	return cols
+}
+
+// Schema returns the schema of every service, narrowed to the service, command and join unless empty.
+func Schema(service, command, join string) ([]ServiceSchema, error) {
+	if service == "" && (command != "" || join != "") {
+		return nil, fmt.Errorf("invalid schema selection: service is required to select a command or join")
+	}
+	if command == "" && join != "" {
+		return nil, fmt.Errorf("invalid schema selection: command is required to select a join")
+	}
+	svcs, err := selectServices(service)
+	if err != nil {
+		return nil, err
+	}
+	var schema []ServiceSchema
+	for _, s := range svcs {
+		cmds, err := s.selectCommands(command)
+		if err != nil {
+			return nil, err
+		}
+		ss := ServiceSchema{Service: s.Name}
+		for _, cmd := range cmds {
+			joins := cmd.Joins
+			if join != "" {
+				j, err := cmd.Find(join)
+				if err != nil {
+					return nil, err
+				}
+				joins = []Joiner{j}
+			}
+			cs := CommandSchema{Command: cmd.Name}
+			for _, j := range joins {
+				cs.Joins = append(cs.Joins, JoinSchema{
+					Join:        j.JoinName(),
+					Default:     j == cmd.Joins[0],
+					MergeFields: append([]int{}, j.DefaultMergeFields()...),
+					Columns:     j.Columns(),
+				})
+			}
+			ss.Commands = append(ss.Commands, cs)
+		}
+		schema = append(schema, ss)
+	}
+	return schema, nil
+}
+
+// Flatten returns one row per column of the schema.
+func Flatten(schema []ServiceSchema) []SchemaInfo {
+	var info []SchemaInfo
+	for _, s := range schema {
+		for _, c := range s.Commands {
+			for _, j := range c.Joins {
+				for _, col := range j.Columns {
+					info = append(info, SchemaInfo{
+						Service:  s.Service,
+						Command:  c.Command,
+						Join:     j.Join,
+						Index:    col.Index,
+						Column:   col.Name,
+						Type:     col.Type,
+						Document: col.Document,
+					})
+				}
+			}
+		}
+	}
+	return info
+}
+
+func selectServices(name string) ([]*Service, error) {
+	if name == "" {
+		return services, nil
+	}
+	names := make([]string, 0, len(services))
+	for _, s := range services {
+		if s.Name == name {
+			return []*Service{s}, nil
+		}
+		names = append(names, s.Name)
+	}
+	return nil, fmt.Errorf("invalid value: %s: valid values: %s", name, strings.Join(names, "|"))
+}
+
+func (s *Service) selectCommands(name string) ([]*Command, error) {
+	if name == "" {
+		return s.Commands, nil
+	}
+	names := make([]string, 0, len(s.Commands))
+	for _, cmd := range s.Commands {
+		if cmd.Name == name {
+			return []*Command{cmd}, nil
+		}
+		names = append(names, cmd.Name)
+	}
+	return nil, fmt.Errorf("invalid value: %s: valid values: %s", name, strings.Join(names, "|"))
+}
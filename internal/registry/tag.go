@@ -0,0 +1,238 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// Tagger returns the tags of the resource each row belongs to, restricted to the keys.
+type Tagger[T any] func(ctx context.Context, opts *Options, rows []T, keys []string) ([]map[string]string, error)
+
+// ec2Tags looks up the tags of EC2 resources by the id of the row in every target region.
+func ec2Tags[T any](id func(row T) string) Tagger[T] {
+	return func(ctx context.Context, opts *Options, rows []T, keys []string) ([]map[string]string, error) {
+		client, err := opts.ec2Client()
+		if err != nil {
+			return nil, err
+		}
+		var mu sync.Mutex
+		res := make(map[string]map[string]string)
+		eg, ctx := errgroup.WithContext(ctx)
+		for _, region := range opts.regions() {
+			eg.Go(func() error {
+				tags, err := client.FetchTags(ctx, region, keys)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				maps.Copy(res, tags)
+				return nil
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			return nil, err
+		}
+		return tagsByRow(rows, id, res), nil
+	}
+}
+
+// iamUserTags looks up the tags of IAM users by the user name of the row.
+func iamUserTags[T any](name func(row T) string) Tagger[T] {
+	return func(ctx context.Context, opts *Options, rows []T, _ []string) ([]map[string]string, error) {
+		client, err := opts.iamClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err := fetchTags(ctx, rows, name, func(ctx context.Context, name string) (map[string]string, error) {
+			return client.GetUserTags(ctx, aws.String(name))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tagsByRow(rows, name, res), nil
+	}
+}
+
+// iamRoleTags looks up the tags of IAM roles by the role name of the row.
+func iamRoleTags[T any](name func(row T) string) Tagger[T] {
+	return func(ctx context.Context, opts *Options, rows []T, _ []string) ([]map[string]string, error) {
+		client, err := opts.iamClient()
+		if err != nil {
+			return nil, err
+		}
+		res, err := fetchTags(ctx, rows, name, func(ctx context.Context, name string) (map[string]string, error) {
+			return client.GetRoleTags(ctx, aws.String(name))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tagsByRow(rows, name, res), nil
+	}
+}
+
+// s3BucketTags looks up the tags of S3 buckets by the bucket name and location of the row.
+// Buckets without a known location are treated as untagged.
+func s3BucketTags[T any](name, location func(row T) string) Tagger[T] {
+	return func(ctx context.Context, opts *Options, rows []T, _ []string) ([]map[string]string, error) {
+		client, err := opts.s3Client()
+		if err != nil {
+			return nil, err
+		}
+		regions := make(map[string]string, len(rows))
+		for _, row := range rows {
+			regions[name(row)] = location(row)
+		}
+		res, err := fetchTags(ctx, rows, name, func(ctx context.Context, name string) (map[string]string, error) {
+			if regions[name] == "" {
+				return nil, nil
+			}
+			return client.GetBucketTags(ctx, aws.String(name), regions[name])
+		})
+		if err != nil {
+			return nil, err
+		}
+		return tagsByRow(rows, name, res), nil
+	}
+}
+
+// fetchTags calls fetch once per distinct id of the rows in parallel.
+func fetchTags[T any](ctx context.Context, rows []T, id func(row T) string, fetch func(ctx context.Context, id string) (map[string]string, error)) (map[string]map[string]string, error) {
+	var ids []string
+	for _, row := range rows {
+		ids = append(ids, id(row))
+	}
+	slices.Sort(ids)
+	var mu sync.Mutex
+	res := make(map[string]map[string]string)
+	eg, ctx := errgroup.WithContext(ctx)
+	l := rate.NewLimiter(rate.Limit(50), 1)
+	for _, id := range slices.Compact(ids) {
+		eg.Go(func() error {
+			if err := l.Wait(ctx); err != nil {
+				return err
+			}
+			tags, err := fetch(ctx, id)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			res[id] = tags
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func tagsByRow[T any](rows []T, id func(row T) string, tags map[string]map[string]string) []map[string]string {
+	res := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		res[i] = tags[id(row)]
+	}
+	return res
+}
+
+// matchTags reports whether the tags have every selected key with any of its values,
+// or the key at all when no value is selected.
+func matchTags(tags map[string]string, selected map[string][]string) bool {
+	for k, vs := range selected {
+		v, ok := tags[k]
+		if !ok {
+			return false
+		}
+		if len(vs) > 0 && !slices.Contains(vs, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagFilters converts the selected tags to EC2 filters narrowing the describe API.
+func tagFilters(selected map[string][]string) []types.Filter {
+	var filters []types.Filter
+	var keys, selectedKeys []string
+	for k := range selected {
+		selectedKeys = append(selectedKeys, k)
+	}
+	slices.Sort(selectedKeys)
+	for _, k := range selectedKeys {
+		if len(selected[k]) == 0 {
+			keys = append(keys, k)
+			continue
+		}
+		filters = append(filters, types.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: selected[k],
+		})
+	}
+	if len(keys) > 0 {
+		// Any of the keys narrows the request; every key is checked on the rows.
+		filters = append(filters, types.Filter{
+			Name:   aws.String("tag-key"),
+			Values: keys,
+		})
+	}
+	return filters
+}
+
+// tagColumn returns the column name of a tag key, which is a Go identifier.
+func tagColumn(key string) string {
+	return "Tag_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// withTagColumns returns the rows as structs extended with a column per tag key.
+func withTagColumns[T any](rows []T, keys []string, tags []map[string]string) (any, error) {
+	t := reflect.TypeFor[T]()
+	var fields []reflect.StructField
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() {
+			fields = append(fields, f)
+			names[f.Name] = true
+		}
+	}
+	for _, k := range keys {
+		name := tagColumn(k)
+		if names[name] {
+			return nil, fmt.Errorf("invalid value: %s: tag column %s is duplicated", k, name)
+		}
+		names[name] = true
+		fields = append(fields, reflect.StructField{
+			Name: name,
+			Type: reflect.TypeFor[string](),
+		})
+	}
+	st := reflect.StructOf(fields)
+	res := reflect.MakeSlice(reflect.SliceOf(st), len(rows), len(rows))
+	for i, row := range rows {
+		v := reflect.ValueOf(row)
+		r := res.Index(i)
+		for _, f := range fields[:len(fields)-len(keys)] {
+			r.FieldByName(f.Name).Set(v.FieldByName(f.Name))
+		}
+		for _, k := range keys {
+			r.FieldByName(tagColumn(k)).SetString(tags[i][k])
+		}
+	}
+	return res.Interface(), nil
+}
@@ -1,29 +0,0 @@
-// Code generated by table/gen.go. DO NOT EDIT.
-
-package ec2
-
-import (
-	"sort"
-
-	"github.com/nekrassov01/aws-describer/internal/api/ec2"
-	"github.com/nekrassov01/aws-describer/internal/tab"
-)
-
-func PrintSecurityGroupInfo(info []ec2.SecurityGroupInfo, output string, header bool, mergeFields, ignoreFields []int) error {
-	sort.SliceStable(info, func(i, j int) bool {
-		if info[i].Region != info[j].Region {
-			return info[i].Region < info[j].Region
-		}
-		if info[i].SecurityGroupName != info[j].SecurityGroupName {
-			return info[i].SecurityGroupName < info[j].SecurityGroupName
-		}
-		if info[i].VpcName != info[j].VpcName {
-			return info[i].VpcName < info[j].VpcName
-		}
-		return info[i].VpcName < info[j].VpcName
-	})
-	if err := tab.PrintTable(info, output, header, mergeFields, ignoreFields); err != nil {
-		return err
-	}
-	return nil
-}
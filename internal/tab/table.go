@@ -1,8 +1,3 @@
-//go:generate go run gen.go
-//go:generate gofmt -w ec2/
-//go:generate gofmt -w iam/
-//go:generate gofmt -w s3/
-
 package tab
 
 import (
@@ -0,0 +1,57 @@
+// Package describer lists AWS resources joined with their related resources.
+//
+// It is the library behind the aws-describer CLI. Every function takes a context
+// for cancellation and an Options value, and returns typed rows in the order
+// the CLI prints them.
+// The package follows semantic versioning: see Version.
+package describer
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/nekrassov01/aws-describer/internal/api"
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"github.com/nekrassov01/aws-describer/internal/api/elb"
+	"github.com/nekrassov01/aws-describer/internal/api/elbv2"
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"github.com/nekrassov01/aws-describer/internal/api/s3"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+)
+
+// DefaultRegion is the region used when neither the options nor the shared config set one.
+const DefaultRegion = api.DefaultRegion
+
+// DefaultRegions lists the regions requested by EC2 functions when Options.Regions is empty.
+var DefaultRegions = append([]string(nil), api.DefaultTargetRegions...)
+
+// Client interfaces accepted by Options. The default implementations are created from Options.Config.
+type (
+	Ec2Client   = ec2.IEc2Client
+	ElbClient   = elb.IElbClient
+	Elbv2Client = elbv2.IElbClient
+	IamClient   = iam.IIamClient
+	S3Client    = s3.IS3Client
+)
+
+// Options configures a request. Fields that do not apply to a function are ignored.
+// Regions defaults to DefaultRegions and Scope to "local".
+// Config is used to create any client that is not set explicitly.
+type Options = registry.Options
+
+// AuditRules configures the audit of security group rules in Options.AuditRules.
+type AuditRules = ec2.AuditRules
+
+// DefaultAuditRules are the audit rules applied when Options.AuditRules is nil.
+var DefaultAuditRules = ec2.DefaultAuditRules
+
+// ParseAuditRules parses a jsonnet file evaluating to audit rules.
+// Fields missing in the file keep the values of DefaultAuditRules.
+func ParseAuditRules(path string) (*AuditRules, error) {
+	return ec2.ParseEc2AuditRules(path)
+}
+
+// LoadConfig loads the shared AWS config for the region and profile. Both may be empty.
+func LoadConfig(ctx context.Context, region string, profile string) (*aws.Config, error) {
+	return api.LoadConfig(ctx, region, profile)
+}
@@ -0,0 +1,359 @@
+package describer
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/ec2"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+)
+
+// Rows returned by the EC2 functions.
+type (
+	InstanceInfo                        = ec2.InstanceInfo
+	InstanceSecurityGroupInfo           = ec2.InstanceSecurityGroupInfo
+	InstanceSecurityGroupAuditInfo      = ec2.InstanceSecurityGroupAuditInfo
+	InstanceSecurityGroupRedundancyInfo = ec2.InstanceSecurityGroupRedundancyInfo
+	InstanceExposureInfo                = ec2.InstanceExposureInfo
+	InstanceRouteInfo                   = ec2.InstanceRouteInfo
+	InstanceStorageInfo                 = ec2.InstanceStorageInfo
+	InstanceBackupInfo                  = ec2.InstanceBackupInfo
+	InstanceLoadBalancerInfo            = ec2.InstanceLoadBalancerInfo
+	ImageInfo                           = ec2.ImageInfo
+	ImageBackupInfo                     = ec2.ImageBackupInfo
+	SecurityGroupInfo                   = ec2.SecurityGroupInfo
+	SecurityGroupPermissionsInfo        = ec2.SecurityGroupPermissionsInfo
+	SecurityGroupUsageInfo              = ec2.SecurityGroupUsageInfo
+	SecurityGroupAuditInfo              = ec2.SecurityGroupAuditInfo
+	SecurityGroupRedundancyInfo         = ec2.SecurityGroupRedundancyInfo
+	SecurityGroupReferenceInfo          = ec2.SecurityGroupReferenceInfo
+	VpcInfo                             = ec2.VpcInfo
+	VpcAttributeInfo                    = ec2.VpcAttributeInfo
+	VpcCidrInfo                         = ec2.VpcCidrInfo
+	VpcGatewayInfo                      = ec2.VpcGatewayInfo
+	VpcCapacityInfo                     = ec2.VpcCapacityInfo
+	VpcCidrOverlapInfo                  = ec2.VpcCidrOverlapInfo
+	SubnetInfo                          = ec2.SubnetInfo
+	SubnetRouteInfo                     = ec2.SubnetRouteInfo
+	RouteTableInfo                      = ec2.RouteTableInfo
+	RouteTableAssociationInfo           = ec2.RouteTableAssociationInfo
+	RouteTableAuditInfo                 = ec2.RouteTableAuditInfo
+	SubnetNetworkAclInfo                = ec2.SubnetNetworkAclInfo
+	NetworkAclInfo                      = ec2.NetworkAclInfo
+	NetworkAclEntryInfo                 = ec2.NetworkAclEntryInfo
+	NetworkAclAssociationInfo           = ec2.NetworkAclAssociationInfo
+	NetworkInterfaceInfo                = ec2.NetworkInterfaceInfo
+	NetworkInterfaceAttachmentInfo      = ec2.NetworkInterfaceAttachmentInfo
+	NetworkInterfaceSecurityGroupInfo   = ec2.NetworkInterfaceSecurityGroupInfo
+	NetworkInterfaceIpInfo              = ec2.NetworkInterfaceIpInfo
+	VolumeInfo                          = ec2.VolumeInfo
+	VolumeSnapshotInfo                  = ec2.VolumeSnapshotInfo
+	SnapshotInfo                        = ec2.SnapshotInfo
+	SnapshotLineageInfo                 = ec2.SnapshotLineageInfo
+	SnapshotPermissionInfo              = ec2.SnapshotPermissionInfo
+	AddressInfo                         = ec2.AddressInfo
+	AddressAssociationInfo              = ec2.AddressAssociationInfo
+	TransitGatewayInfo                  = ec2.TransitGatewayInfo
+	TransitGatewayAttachmentInfo        = ec2.TransitGatewayAttachmentInfo
+	TransitGatewayRouteTableInfo        = ec2.TransitGatewayRouteTableInfo
+	TransitGatewayRouteInfo             = ec2.TransitGatewayRouteInfo
+	VpcPeeringConnectionInfo            = ec2.VpcPeeringConnectionInfo
+	VpcPeeringConnectionCidrInfo        = ec2.VpcPeeringConnectionCidrInfo
+	VpcPeeringConnectionRouteInfo       = ec2.VpcPeeringConnectionRouteInfo
+	ReachabilityInfo                    = ec2.ReachabilityInfo
+	PlannedSubnetInfo                   = ec2.PlannedSubnetInfo
+)
+
+// DescribeInstanceInfo lists EC2 instances.
+func DescribeInstanceInfo(ctx context.Context, opts *Options) ([]InstanceInfo, error) {
+	return registry.Instance.Rows(ctx, opts)
+}
+
+// DescribeInstanceSecurityGroupInfo lists EC2 instances with the rules of their security groups.
+// Rules referencing a prefix list are expanded to its CIDR blocks when Options.ExpandPrefixLists is set.
+func DescribeInstanceSecurityGroupInfo(ctx context.Context, opts *Options) ([]InstanceSecurityGroupInfo, error) {
+	return registry.InstanceSecurityGroup.Rows(ctx, opts)
+}
+
+// DescribeInstanceSecurityGroupAuditInfo lists EC2 instances with the risky ingress rules of their security groups,
+// flagged by severity according to Options.AuditRules.
+// Rules referencing a prefix list are audited per CIDR block when Options.ExpandPrefixLists is set.
+func DescribeInstanceSecurityGroupAuditInfo(ctx context.Context, opts *Options) ([]InstanceSecurityGroupAuditInfo, error) {
+	return registry.InstanceSecurityGroupAudit.Rows(ctx, opts)
+}
+
+// DescribeInstanceSecurityGroupRedundancyInfo lists EC2 instances with the rules of their security groups
+// that are duplicated or covered by other rules of any attached group, with suggestions for consolidation.
+// Prefix lists are compared as written, whatever Options.ExpandPrefixLists.
+func DescribeInstanceSecurityGroupRedundancyInfo(ctx context.Context, opts *Options) ([]InstanceSecurityGroupRedundancyInfo, error) {
+	return registry.InstanceSecurityGroupRedundancy.Rows(ctx, opts)
+}
+
+// DescribeInstanceExposureInfo lists EC2 instances with the ports their security groups open to the internet,
+// and whether the public address, the route to an internet gateway and the network ACL let the traffic through.
+// Rules open to the internet through a prefix list are included when Options.ExpandPrefixLists is set.
+func DescribeInstanceExposureInfo(ctx context.Context, opts *Options) ([]InstanceExposureInfo, error) {
+	return registry.InstanceExposure.Rows(ctx, opts)
+}
+
+// DescribeInstanceRouteInfo lists EC2 instances with the routes of their subnets.
+// Routes to a prefix list are expanded to its CIDR blocks when Options.ExpandPrefixLists is set.
+func DescribeInstanceRouteInfo(ctx context.Context, opts *Options) ([]InstanceRouteInfo, error) {
+	return registry.InstanceRoute.Rows(ctx, opts)
+}
+
+// DescribeInstanceStorageInfo lists EC2 instances with their EBS volumes.
+func DescribeInstanceStorageInfo(ctx context.Context, opts *Options) ([]InstanceStorageInfo, error) {
+	return registry.InstanceStorage.Rows(ctx, opts)
+}
+
+// DescribeInstanceBackupInfo lists EC2 instances with their source images, volumes and snapshots.
+func DescribeInstanceBackupInfo(ctx context.Context, opts *Options) ([]InstanceBackupInfo, error) {
+	return registry.InstanceBackup.Rows(ctx, opts)
+}
+
+// DescribeInstanceLoadBalancerInfo lists EC2 instances registered to load balancers and target groups.
+func DescribeInstanceLoadBalancerInfo(ctx context.Context, opts *Options) ([]InstanceLoadBalancerInfo, error) {
+	return registry.InstanceLoadBalancer.Rows(ctx, opts)
+}
+
+// DescribeImageInfo lists EC2 images.
+func DescribeImageInfo(ctx context.Context, opts *Options) ([]ImageInfo, error) {
+	return registry.Image.Rows(ctx, opts)
+}
+
+// DescribeImageBackupInfo lists EC2 images with their snapshots and source volumes.
+func DescribeImageBackupInfo(ctx context.Context, opts *Options) ([]ImageBackupInfo, error) {
+	return registry.ImageBackup.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupInfo lists EC2 security groups.
+func DescribeSecurityGroupInfo(ctx context.Context, opts *Options) ([]SecurityGroupInfo, error) {
+	return registry.SecurityGroup.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupPermissionsInfo lists EC2 security groups with their rules.
+// Rules referencing a prefix list are expanded to its CIDR blocks when Options.ExpandPrefixLists is set.
+func DescribeSecurityGroupPermissionsInfo(ctx context.Context, opts *Options) ([]SecurityGroupPermissionsInfo, error) {
+	return registry.SecurityGroupPermissions.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupUsageInfo lists EC2 security groups with the network interfaces attached to them
+// and the instances, load balancers, Lambda functions or other services owning those interfaces.
+func DescribeSecurityGroupUsageInfo(ctx context.Context, opts *Options) ([]SecurityGroupUsageInfo, error) {
+	return registry.SecurityGroupUsage.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupReferenceInfo lists EC2 security groups with the rules of other groups referencing them,
+// including stale references to deleted groups and groups of VPCs no longer peered.
+func DescribeSecurityGroupReferenceInfo(ctx context.Context, opts *Options) ([]SecurityGroupReferenceInfo, error) {
+	return registry.SecurityGroupReference.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupAuditInfo lists the risky ingress rules of EC2 security groups,
+// flagged by severity according to Options.AuditRules.
+// Rules referencing a prefix list are audited per CIDR block when Options.ExpandPrefixLists is set.
+func DescribeSecurityGroupAuditInfo(ctx context.Context, opts *Options) ([]SecurityGroupAuditInfo, error) {
+	return registry.SecurityGroupAudit.Rows(ctx, opts)
+}
+
+// DescribeSecurityGroupRedundancyInfo lists the rules of EC2 security groups that are duplicated or covered
+// by other rules of the same group, or can be merged with them, with suggestions for consolidation.
+// Prefix lists are compared as written, whatever Options.ExpandPrefixLists.
+func DescribeSecurityGroupRedundancyInfo(ctx context.Context, opts *Options) ([]SecurityGroupRedundancyInfo, error) {
+	return registry.SecurityGroupRedundancy.Rows(ctx, opts)
+}
+
+// DescribeVpcInfo lists VPCs.
+func DescribeVpcInfo(ctx context.Context, opts *Options) ([]VpcInfo, error) {
+	return registry.Vpc.Rows(ctx, opts)
+}
+
+// DescribeVpcAttributeInfo lists VPCs with their DNS attributes.
+func DescribeVpcAttributeInfo(ctx context.Context, opts *Options) ([]VpcAttributeInfo, error) {
+	return registry.VpcAttribute.Rows(ctx, opts)
+}
+
+// DescribeVpcCidrInfo lists VPCs with their IPv4 and IPv6 CIDR blocks.
+func DescribeVpcCidrInfo(ctx context.Context, opts *Options) ([]VpcCidrInfo, error) {
+	return registry.VpcCidr.Rows(ctx, opts)
+}
+
+// DescribeVpcGatewayInfo lists VPCs with their gateways, endpoints, peering connections
+// and transit gateway attachments.
+func DescribeVpcGatewayInfo(ctx context.Context, opts *Options) ([]VpcGatewayInfo, error) {
+	return registry.VpcGateway.Rows(ctx, opts)
+}
+
+// DescribeVpcCapacityInfo lists VPCs with the IPv4 addresses of their CIDR blocks and subnets.
+// VPCs using less than Options.MinUtilization percent of their subnet addresses are skipped.
+func DescribeVpcCapacityInfo(ctx context.Context, opts *Options) ([]VpcCapacityInfo, error) {
+	return registry.VpcCapacity.Rows(ctx, opts)
+}
+
+// DescribeVpcCidrOverlapInfo lists the CIDR blocks of the VPCs selected by Options.Ids, Options.Names,
+// Options.Filters and Options.Tags that overlap a block of any other VPC in the regions, from the side of the selected VPC.
+func DescribeVpcCidrOverlapInfo(ctx context.Context, opts *Options) ([]VpcCidrOverlapInfo, error) {
+	return registry.VpcCidrOverlap.Rows(ctx, opts)
+}
+
+// FindVpcCidrOverlaps returns the overlapping CIDR blocks of the VPCs, a row from each side of a pair. Combine the rows
+// of DescribeVpcCidrInfo requested with the config of each account to compare VPCs across accounts.
+func FindVpcCidrOverlaps(cidrs []VpcCidrInfo) []VpcCidrOverlapInfo {
+	return ec2.GetVpcCidrOverlapInfo(cidrs, nil)
+}
+
+// DescribeSubnetInfo lists subnets with their IPv4 capacity.
+// Subnets using less than Options.MinUtilization percent of their addresses are skipped,
+// and so are IPv6-only subnets when it is set.
+func DescribeSubnetInfo(ctx context.Context, opts *Options) ([]SubnetInfo, error) {
+	return registry.Subnet.Rows(ctx, opts)
+}
+
+// DescribeSubnetRouteInfo lists subnets with the routes of their route tables.
+// Routes to a prefix list are expanded to its CIDR blocks when Options.ExpandPrefixLists is set.
+func DescribeSubnetRouteInfo(ctx context.Context, opts *Options) ([]SubnetRouteInfo, error) {
+	return registry.SubnetRoute.Rows(ctx, opts)
+}
+
+// DescribeRouteTableInfo lists route tables with their routes.
+// Routes to a prefix list are expanded to its CIDR blocks when Options.ExpandPrefixLists is set.
+func DescribeRouteTableInfo(ctx context.Context, opts *Options) ([]RouteTableInfo, error) {
+	return registry.RouteTable.Rows(ctx, opts)
+}
+
+// DescribeRouteTableAssociationInfo lists route tables with their subnet associations.
+func DescribeRouteTableAssociationInfo(ctx context.Context, opts *Options) ([]RouteTableAssociationInfo, error) {
+	return registry.RouteTableAssociation.Rows(ctx, opts)
+}
+
+// DescribeRouteTableAuditInfo lists blackhole routes, routes to missing or unavailable targets
+// and route tables without associations.
+func DescribeRouteTableAuditInfo(ctx context.Context, opts *Options) ([]RouteTableAuditInfo, error) {
+	return registry.RouteTableAudit.Rows(ctx, opts)
+}
+
+// DescribeSubnetNetworkAclInfo lists subnets with the rules of their network ACLs.
+func DescribeSubnetNetworkAclInfo(ctx context.Context, opts *Options) ([]SubnetNetworkAclInfo, error) {
+	return registry.SubnetNetworkAcl.Rows(ctx, opts)
+}
+
+// DescribeNetworkAclInfo lists network ACLs.
+func DescribeNetworkAclInfo(ctx context.Context, opts *Options) ([]NetworkAclInfo, error) {
+	return registry.NetworkAcl.Rows(ctx, opts)
+}
+
+// DescribeNetworkAclEntryInfo lists network ACLs with their rules in evaluation order.
+func DescribeNetworkAclEntryInfo(ctx context.Context, opts *Options) ([]NetworkAclEntryInfo, error) {
+	return registry.NetworkAclEntry.Rows(ctx, opts)
+}
+
+// DescribeNetworkAclAssociationInfo lists network ACLs with their subnet associations.
+func DescribeNetworkAclAssociationInfo(ctx context.Context, opts *Options) ([]NetworkAclAssociationInfo, error) {
+	return registry.NetworkAclAssociation.Rows(ctx, opts)
+}
+
+// DescribeNetworkInterfaceInfo lists EC2 network interfaces.
+func DescribeNetworkInterfaceInfo(ctx context.Context, opts *Options) ([]NetworkInterfaceInfo, error) {
+	return registry.NetworkInterface.Rows(ctx, opts)
+}
+
+// DescribeNetworkInterfaceAttachmentInfo lists EC2 network interfaces with the instances or services owning them.
+func DescribeNetworkInterfaceAttachmentInfo(ctx context.Context, opts *Options) ([]NetworkInterfaceAttachmentInfo, error) {
+	return registry.NetworkInterfaceAttachment.Rows(ctx, opts)
+}
+
+// DescribeNetworkInterfaceSecurityGroupInfo lists EC2 network interfaces with the rules of their security groups.
+func DescribeNetworkInterfaceSecurityGroupInfo(ctx context.Context, opts *Options) ([]NetworkInterfaceSecurityGroupInfo, error) {
+	return registry.NetworkInterfaceSecurityGroup.Rows(ctx, opts)
+}
+
+// DescribeNetworkInterfaceIpInfo lists EC2 network interfaces with their private, public and elastic IP addresses.
+func DescribeNetworkInterfaceIpInfo(ctx context.Context, opts *Options) ([]NetworkInterfaceIpInfo, error) {
+	return registry.NetworkInterfaceIp.Rows(ctx, opts)
+}
+
+// DescribeVolumeInfo lists EBS volumes with the instances they are attached to.
+func DescribeVolumeInfo(ctx context.Context, opts *Options) ([]VolumeInfo, error) {
+	return registry.Volume.Rows(ctx, opts)
+}
+
+// DescribeVolumeSnapshotInfo lists EBS volumes with the snapshots they were created from.
+func DescribeVolumeSnapshotInfo(ctx context.Context, opts *Options) ([]VolumeSnapshotInfo, error) {
+	return registry.VolumeSnapshot.Rows(ctx, opts)
+}
+
+// DescribeSnapshotInfo lists EBS snapshots.
+func DescribeSnapshotInfo(ctx context.Context, opts *Options) ([]SnapshotInfo, error) {
+	return registry.Snapshot.Rows(ctx, opts)
+}
+
+// DescribeSnapshotLineageInfo lists EBS snapshots with their source volumes, the images referencing them
+// and the instances launched from those images.
+func DescribeSnapshotLineageInfo(ctx context.Context, opts *Options) ([]SnapshotLineageInfo, error) {
+	return registry.SnapshotLineage.Rows(ctx, opts)
+}
+
+// DescribeSnapshotPermissionInfo lists EBS snapshots with the accounts allowed to create volumes from them.
+func DescribeSnapshotPermissionInfo(ctx context.Context, opts *Options) ([]SnapshotPermissionInfo, error) {
+	return registry.SnapshotPermission.Rows(ctx, opts)
+}
+
+// DescribeAddressInfo lists elastic IP addresses with what they are associated with.
+func DescribeAddressInfo(ctx context.Context, opts *Options) ([]AddressInfo, error) {
+	return registry.Address.Rows(ctx, opts)
+}
+
+// DescribeAddressAssociationInfo lists elastic IP addresses with the names of the instances,
+// NAT gateways or network interfaces they are associated with.
+func DescribeAddressAssociationInfo(ctx context.Context, opts *Options) ([]AddressAssociationInfo, error) {
+	return registry.AddressAssociation.Rows(ctx, opts)
+}
+
+// DescribeTransitGatewayInfo lists transit gateways.
+func DescribeTransitGatewayInfo(ctx context.Context, opts *Options) ([]TransitGatewayInfo, error) {
+	return registry.TransitGateway.Rows(ctx, opts)
+}
+
+// DescribeTransitGatewayAttachmentInfo lists transit gateways with their VPC, VPN and peering attachments.
+func DescribeTransitGatewayAttachmentInfo(ctx context.Context, opts *Options) ([]TransitGatewayAttachmentInfo, error) {
+	return registry.TransitGatewayAttachment.Rows(ctx, opts)
+}
+
+// DescribeTransitGatewayRouteTableInfo lists transit gateways with the associations and propagations of their route tables.
+func DescribeTransitGatewayRouteTableInfo(ctx context.Context, opts *Options) ([]TransitGatewayRouteTableInfo, error) {
+	return registry.TransitGatewayRouteTable.Rows(ctx, opts)
+}
+
+// DescribeTransitGatewayRouteInfo lists transit gateways with the static and propagated routes of their route tables.
+func DescribeTransitGatewayRouteInfo(ctx context.Context, opts *Options) ([]TransitGatewayRouteInfo, error) {
+	return registry.TransitGatewayRoute.Rows(ctx, opts)
+}
+
+// DescribeVpcPeeringConnectionInfo lists VPC peering connections with the requester and accepter VPCs.
+func DescribeVpcPeeringConnectionInfo(ctx context.Context, opts *Options) ([]VpcPeeringConnectionInfo, error) {
+	return registry.VpcPeeringConnection.Rows(ctx, opts)
+}
+
+// DescribeVpcPeeringConnectionCidrInfo lists VPC peering connections with every CIDR block of both VPCs.
+func DescribeVpcPeeringConnectionCidrInfo(ctx context.Context, opts *Options) ([]VpcPeeringConnectionCidrInfo, error) {
+	return registry.VpcPeeringConnectionCidr.Rows(ctx, opts)
+}
+
+// DescribeVpcPeeringConnectionRouteInfo lists VPC peering connections with whether the route tables
+// of both VPCs route the CIDR blocks of the peer to the connection.
+func DescribeVpcPeeringConnectionRouteInfo(ctx context.Context, opts *Options) ([]VpcPeeringConnectionRouteInfo, error) {
+	return registry.VpcPeeringConnectionRoute.Rows(ctx, opts)
+}
+
+// DescribeReachabilityInfo checks whether Options.Source can reach Options.Destination with Options.Protocol
+// and Options.Port, and lists the security groups, network ACLs and routes the flow meets in order.
+// Checks stop at the first one denying the flow.
+func DescribeReachabilityInfo(ctx context.Context, opts *Options) ([]ReachabilityInfo, error) {
+	return registry.Reachability.Rows(ctx, opts)
+}
+
+// DescribePlannedSubnetInfo lists Options.Count free CIDR blocks of Options.Prefix length in Options.Vpc,
+// not overlapping any of its subnets, assigned to Options.AvailabilityZones in turn.
+func DescribePlannedSubnetInfo(ctx context.Context, opts *Options) ([]PlannedSubnetInfo, error) {
+	return registry.PlannedSubnet.Rows(ctx, opts)
+}
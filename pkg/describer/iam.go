@@ -0,0 +1,72 @@
+package describer
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/iam"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+)
+
+// Rows returned by the IAM functions.
+type (
+	UserInfo            = iam.UserInfo
+	UserPolicyInfo      = iam.UserPolicyInfo
+	UserGroupInfo       = iam.UserGroupInfo
+	UserAssociationInfo = iam.UserAssociationInfo
+	GroupInfo           = iam.GroupInfo
+	GroupPolicyInfo     = iam.GroupPolicyInfo
+	RoleInfo            = iam.RoleInfo
+	RolePolicyInfo      = iam.RolePolicyInfo
+	RoleAssumeInfo      = iam.RoleAssumeInfo
+	PolicyInfo          = iam.PolicyInfo
+)
+
+// ListUserInfo lists IAM users.
+func ListUserInfo(ctx context.Context, opts *Options) ([]UserInfo, error) {
+	return registry.User.Rows(ctx, opts)
+}
+
+// ListUserPolicyInfo lists IAM users with their attached and inline policies.
+func ListUserPolicyInfo(ctx context.Context, opts *Options) ([]UserPolicyInfo, error) {
+	return registry.UserPolicy.Rows(ctx, opts)
+}
+
+// ListUserGroupInfo lists IAM users with the groups they belong to.
+func ListUserGroupInfo(ctx context.Context, opts *Options) ([]UserGroupInfo, error) {
+	return registry.UserGroup.Rows(ctx, opts)
+}
+
+// ListUserAssociationInfo lists IAM users with every policy that applies to them, directly or through groups.
+func ListUserAssociationInfo(ctx context.Context, opts *Options) ([]UserAssociationInfo, error) {
+	return registry.UserAssociation.Rows(ctx, opts)
+}
+
+// ListGroupInfo lists IAM groups.
+func ListGroupInfo(ctx context.Context, opts *Options) ([]GroupInfo, error) {
+	return registry.Group.Rows(ctx, opts)
+}
+
+// ListGroupPolicyInfo lists IAM groups with their attached and inline policies.
+func ListGroupPolicyInfo(ctx context.Context, opts *Options) ([]GroupPolicyInfo, error) {
+	return registry.GroupPolicy.Rows(ctx, opts)
+}
+
+// ListRoleInfo lists IAM roles.
+func ListRoleInfo(ctx context.Context, opts *Options) ([]RoleInfo, error) {
+	return registry.Role.Rows(ctx, opts)
+}
+
+// ListRolePolicyInfo lists IAM roles with their attached and inline policies.
+func ListRolePolicyInfo(ctx context.Context, opts *Options) ([]RolePolicyInfo, error) {
+	return registry.RolePolicy.Rows(ctx, opts)
+}
+
+// ListRoleAssumeInfo lists IAM roles with their trust policies.
+func ListRoleAssumeInfo(ctx context.Context, opts *Options) ([]RoleAssumeInfo, error) {
+	return registry.RoleAssume.Rows(ctx, opts)
+}
+
+// ListPolicyInfo lists IAM managed policies in the scope of Options.Scope.
+func ListPolicyInfo(ctx context.Context, opts *Options) ([]PolicyInfo, error) {
+	return registry.Policy.Rows(ctx, opts)
+}
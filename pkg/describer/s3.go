@@ -0,0 +1,18 @@
+package describer
+
+import (
+	"context"
+
+	"github.com/nekrassov01/aws-describer/internal/api/s3"
+	"github.com/nekrassov01/aws-describer/internal/registry"
+)
+
+// Rows returned by the S3 functions.
+type (
+	BucketInfo = s3.BucketInfo
+)
+
+// ListBucketInfo lists S3 buckets with their location and bucket policy.
+func ListBucketInfo(ctx context.Context, opts *Options) ([]BucketInfo, error) {
+	return registry.Bucket.Rows(ctx, opts)
+}